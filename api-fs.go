@@ -0,0 +1,383 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// BucketFS exposes the objects of a bucket, optionally below a prefix, as
+// a read-only io/fs.FS. Directories are synthesized from delimited
+// listings on "/" and files are backed by *Object so that Seek and ReadAt
+// translate to ranged GET requests.
+//
+// BucketFS implements fs.ReadDirFS, fs.StatFS, fs.ReadFileFS and fs.SubFS.
+type BucketFS struct {
+	ctx        context.Context
+	client     *Client
+	bucketName string
+	prefix     string
+	opts       GetObjectOptions
+}
+
+// Static interface checks.
+var (
+	_ fs.ReadDirFS  = (*BucketFS)(nil)
+	_ fs.StatFS     = (*BucketFS)(nil)
+	_ fs.ReadFileFS = (*BucketFS)(nil)
+	_ fs.SubFS      = (*BucketFS)(nil)
+)
+
+// FS returns a read-only io/fs.FS over the objects of bucketName below
+// prefix. The context is used for all requests made through the returned
+// file system, opts are passed to every GetObject and StatObject call, for
+// example to provide SSE-C keys.
+//
+//	api := client.New(....)
+//	fsys, err := api.FS(ctx, "mybucket", "templates/", minio.GetObjectOptions{})
+//	if err != nil {
+//	    log.Fatalln(err)
+//	}
+//	tmpl, err := template.ParseFS(fsys, "*.html")
+func (c *Client) FS(ctx context.Context, bucketName, prefix string, opts GetObjectOptions) (*BucketFS, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectNamePrefix(prefix); err != nil {
+		return nil, err
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &BucketFS{
+		ctx:        ctx,
+		client:     c,
+		bucketName: bucketName,
+		prefix:     prefix,
+		opts:       opts,
+	}, nil
+}
+
+// objectName returns the object name for the fs path name.
+func (fsys *BucketFS) objectName(name string) string {
+	if name == "." {
+		return fsys.prefix
+	}
+	return fsys.prefix + name
+}
+
+// dirPrefix returns the listing prefix for the directory name.
+func (fsys *BucketFS) dirPrefix(name string) string {
+	if name == "." {
+		return fsys.prefix
+	}
+	return fsys.prefix + name + "/"
+}
+
+// Open opens the named file or directory.
+func (fsys *BucketFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if name != "." {
+		obj, err := fsys.client.GetObject(fsys.ctx, fsys.bucketName, fsys.objectName(name), fsys.opts)
+		if err == nil {
+			var info ObjectInfo
+			info, err = obj.Stat()
+			if err == nil {
+				return &bucketFile{Object: obj, info: info}, nil
+			}
+			obj.Close()
+		}
+		if !isNotExist(err) {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+	}
+	info, err := fsys.statDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &bucketDir{fsys: fsys, name: name, info: info}, nil
+}
+
+// Stat returns a fs.FileInfo describing the named file or directory.
+func (fsys *BucketFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if name != "." {
+		info, err := fsys.client.StatObject(fsys.ctx, fsys.bucketName, fsys.objectName(name), fsys.opts)
+		if err == nil {
+			return &bucketFileInfo{info: info}, nil
+		}
+		if !isNotExist(err) {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+		}
+	}
+	info, err := fsys.statDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// ReadFile reads the named file and returns its contents.
+func (fsys *BucketFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errIsDir}
+	}
+	obj, err := fsys.client.GetObject(fsys.ctx, fsys.bucketName, fsys.objectName(name), fsys.opts)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: toFSError(err)}
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if isNotExist(err) {
+			if _, derr := fsys.statDir(name); derr == nil {
+				err = errIsDir
+			}
+		}
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: toFSError(err)}
+	}
+	return data, nil
+}
+
+// ReadDir reads the named directory and returns its entries sorted by name.
+func (fsys *BucketFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, err := fsys.readDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// Sub returns a BucketFS corresponding to the subtree rooted at dir.
+func (fsys *BucketFS) Sub(dir string) (fs.FS, error) {
+	if !fs.ValidPath(dir) {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid}
+	}
+	if dir == "." {
+		return fsys, nil
+	}
+	sub := *fsys
+	sub.prefix = fsys.dirPrefix(dir)
+	return &sub, nil
+}
+
+// readDir lists the entries of the directory name, a directory without
+// any entries does not exist unless it is the root.
+func (fsys *BucketFS) readDir(name string) ([]fs.DirEntry, error) {
+	prefix := fsys.dirPrefix(name)
+
+	ctx, cancel := context.WithCancel(fsys.ctx)
+	defer cancel()
+
+	var entries []fs.DirEntry
+	for obj := range fsys.client.ListObjectsIter(ctx, fsys.bucketName, ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return nil, toFSError(obj.Err)
+		}
+		base := strings.TrimPrefix(obj.Key, prefix)
+		isDir := strings.HasSuffix(base, "/")
+		base = strings.TrimSuffix(base, "/")
+		// Skip directory markers and names that are
+		// not representable as fs paths.
+		if base == "" || !fs.ValidPath(base) || strings.Contains(base, "/") {
+			continue
+		}
+		if isDir {
+			entries = append(entries, &bucketFileInfo{name: base, dir: true})
+			continue
+		}
+		obj.Key = base
+		entries = append(entries, &bucketFileInfo{info: obj})
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, fs.ErrNotExist
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+// statDir returns the synthesized file info of the directory name.
+func (fsys *BucketFS) statDir(name string) (*bucketFileInfo, error) {
+	if name != "." {
+		ctx, cancel := context.WithCancel(fsys.ctx)
+		defer cancel()
+
+		var found bool
+		for obj := range fsys.client.ListObjectsIter(ctx, fsys.bucketName, ListObjectsOptions{
+			Prefix:  fsys.dirPrefix(name),
+			MaxKeys: 1,
+		}) {
+			if obj.Err != nil {
+				return nil, toFSError(obj.Err)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return nil, fs.ErrNotExist
+		}
+	}
+	return &bucketFileInfo{name: path.Base(name), dir: true}, nil
+}
+
+var errIsDir = errors.New("is a directory")
+
+// isNotExist returns true if err reports a missing object.
+func isNotExist(err error) bool {
+	errResp := ToErrorResponse(err)
+	return errResp.Code == NoSuchKey || errResp.StatusCode == http.StatusNotFound
+}
+
+// toFSError maps missing objects to fs.ErrNotExist.
+func toFSError(err error) error {
+	if isNotExist(err) {
+		return fs.ErrNotExist
+	}
+	return err
+}
+
+// bucketFileInfo implements fs.FileInfo and fs.DirEntry for objects and
+// synthesized directories.
+type bucketFileInfo struct {
+	info ObjectInfo
+	name string
+	dir  bool
+}
+
+func (fi *bucketFileInfo) Name() string {
+	if fi.dir {
+		return fi.name
+	}
+	return path.Base(fi.info.Key)
+}
+
+func (fi *bucketFileInfo) Size() int64 {
+	if fi.dir {
+		return 0
+	}
+	return fi.info.Size
+}
+
+func (fi *bucketFileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+func (fi *bucketFileInfo) ModTime() time.Time { return fi.info.LastModified }
+
+func (fi *bucketFileInfo) IsDir() bool { return fi.dir }
+
+// Sys returns the ObjectInfo of the object, empty for directories.
+func (fi *bucketFileInfo) Sys() any { return fi.info }
+
+func (fi *bucketFileInfo) Type() fs.FileMode { return fi.Mode().Type() }
+
+func (fi *bucketFileInfo) Info() (fs.FileInfo, error) { return fi, nil }
+
+func (fi *bucketFileInfo) String() string { return fs.FormatFileInfo(fi) }
+
+// bucketFile is a fs.File backed by *Object, Seek and ReadAt are
+// provided by the embedded Object.
+type bucketFile struct {
+	*Object
+	info ObjectInfo
+}
+
+func (f *bucketFile) Stat() (fs.FileInfo, error) {
+	return &bucketFileInfo{info: f.info}, nil
+}
+
+// Seek is Object.Seek, additionally allowing negative offsets relative
+// to the current offset as expected from an fs.File.
+func (f *bucketFile) Seek(offset int64, whence int) (int64, error) {
+	if whence != io.SeekCurrent || offset >= 0 {
+		return f.Object.Seek(offset, whence)
+	}
+	cur, err := f.Object.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	if cur+offset < 0 {
+		return 0, errInvalidArgument("Seeking at negative offset not allowed")
+	}
+	return f.Object.Seek(cur+offset, io.SeekStart)
+}
+
+// bucketDir is a fs.ReadDirFile for a synthesized directory, entries are
+// listed on the first ReadDir call.
+type bucketDir struct {
+	fsys    *BucketFS
+	name    string
+	info    *bucketFileInfo
+	entries []fs.DirEntry
+	listed  bool
+}
+
+func (d *bucketDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *bucketDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errIsDir}
+}
+
+func (d *bucketDir) Close() error { return nil }
+
+func (d *bucketDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		entries, err := d.fsys.readDir(d.name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: err}
+		}
+		d.entries, d.listed = entries, true
+	}
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,169 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// newFSTestServer serves the objects as a single bucket supporting
+// ListObjectsV2, GetObject and StatObject.
+func newFSTestServer(t *testing.T, bucket string, objects map[string]string) *Client {
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+bucket), "/")
+		if key == "" {
+			query := r.URL.Query()
+			prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+			var buf strings.Builder
+			buf.WriteString("<ListBucketResult><IsTruncated>false</IsTruncated>")
+			seen := map[string]bool{}
+			keys := make([]string, 0, len(objects))
+			for k := range objects {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				if !strings.HasPrefix(k, prefix) {
+					continue
+				}
+				if i := strings.Index(k[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+					p := k[:len(prefix)+i+1]
+					if !seen[p] {
+						seen[p] = true
+						fmt.Fprintf(&buf, "<CommonPrefixes><Prefix>%s</Prefix></CommonPrefixes>", p)
+					}
+					continue
+				}
+				fmt.Fprintf(&buf, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified><ETag>&quot;%s&quot;</ETag></Contents>",
+					k, len(objects[k]), modTime.Format(time.RFC3339), k)
+			}
+			buf.WriteString("</ListBucketResult>")
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(buf.String()))
+			return
+		}
+		data, ok := objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method != http.MethodHead {
+				w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			}
+			return
+		}
+		w.Header().Set("ETag", `"`+key+`"`)
+		http.ServeContent(w, r, key, modTime, strings.NewReader(data))
+	}))
+	t.Cleanup(ts.Close)
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Secure: false,
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestBucketFS(t *testing.T) {
+	client := newFSTestServer(t, "test-bucket", map[string]string{
+		"site/index.html":         "<html>index</html>",
+		"site/css/main.css":       "body {}",
+		"site/img/logo.png":       "png",
+		"site/img/icons/home.svg": "<svg/>",
+		"site/img/":               "",
+		"other.txt":               "other",
+	})
+
+	fsys, err := client.FS(t.Context(), "test-bucket", "site", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fstest.TestFS(fsys, "index.html", "css/main.css", "img/logo.png", "img/icons/home.svg"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fs.ReadFile(fsys, "img/icons/home.svg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "<svg/>" {
+		t.Errorf("unexpected content %q", data)
+	}
+
+	if _, err = fsys.Open("missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist, got %v", err)
+	}
+
+	f, err := fsys.Open("index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		t.Fatal("expected file to implement io.ReadSeeker")
+	}
+	if _, err = rs.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	data, err = io.ReadAll(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte("index</html>")) {
+		t.Errorf("unexpected content after seek %q", data)
+	}
+
+	sub, err := fs.Sub(fsys, "img")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := fs.ReadDir(sub, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if !slices.Equal(names, []string{"icons", "logo.png"}) {
+		t.Errorf("unexpected entries %v", names)
+	}
+}
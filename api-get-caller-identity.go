@@ -0,0 +1,142 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// CallerIdentity is the identity of the credentials of a client.
+type CallerIdentity struct {
+	// Account the identity belongs to.
+	Account string
+	// Arn of the identity, such as the user or assumed role.
+	Arn string
+	// UserID is the unique identifier of the identity.
+	UserID string `xml:"UserId"`
+}
+
+// getCallerIdentityResponse is the response of the STS GetCallerIdentity
+// action.
+type getCallerIdentityResponse struct {
+	XMLName xml.Name       `xml:"GetCallerIdentityResponse"`
+	Result  CallerIdentity `xml:"GetCallerIdentityResult"`
+}
+
+// stsErrorResponse is an STS error response.
+type stsErrorResponse struct {
+	XMLName xml.Name `xml:"ErrorResponse"`
+	Error   struct {
+		Code    string
+		Message string
+	}
+	RequestID string `xml:"RequestId"`
+}
+
+// GetCallerIdentity returns the identity of the credentials of the client,
+// with the STS GetCallerIdentity action. The action is sent to the AWS STS
+// endpoint of the region for Amazon S3 endpoints, and to the endpoint of
+// the client otherwise.
+func (c *Client) GetCallerIdentity(ctx context.Context) (CallerIdentity, error) {
+	creds, err := c.GetCreds()
+	if err != nil {
+		return CallerIdentity{}, err
+	}
+	if creds.SignerType.IsAnonymous() {
+		return CallerIdentity{}, errInvalidArgument("GetCallerIdentity requires credentials.")
+	}
+
+	location := c.region
+	if location == "" {
+		location = s3utils.GetRegionFromURL(*c.endpointURL)
+	}
+	endpoint := *c.endpointURL
+	if s3utils.IsAmazonEndpoint(*c.endpointURL) {
+		endpoint = url.URL{Scheme: "https", Host: "sts.amazonaws.com"}
+		if strings.HasPrefix(location, "cn-") {
+			endpoint.Host = "sts." + location + ".amazonaws.com.cn"
+		} else if location != "" {
+			endpoint.Host = "sts." + location + ".amazonaws.com"
+		}
+	}
+	endpoint.Path = "/"
+	if location == "" {
+		location = "us-east-1"
+	}
+
+	body := []byte(url.Values{
+		"Action":  {"GetCallerIdentity"},
+		"Version": {credentials.STSVersion},
+	}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return CallerIdentity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Amz-Content-Sha256", sum256Hex(body))
+	if c.externalSigner != nil {
+		req, err = signer.SignV4WithSigner(ctx, *req, c.externalSigner, creds.AccessKeyID, creds.SessionToken, location, signer.ServiceTypeSTS, nil)
+		if err != nil {
+			return CallerIdentity{}, err
+		}
+	} else {
+		if creds.SessionToken != "" {
+			req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+		}
+		req = signer.SignV4STS(*req, creds.AccessKeyID, creds.SecretAccessKey, location)
+	}
+
+	resp, err := c.do(req)
+	defer closeResponse(resp)
+	if err != nil {
+		return CallerIdentity{}, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CallerIdentity{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var stsErr stsErrorResponse
+		if xml.Unmarshal(data, &stsErr) == nil {
+			return CallerIdentity{}, ErrorResponse{
+				StatusCode: resp.StatusCode,
+				Code:       stsErr.Error.Code,
+				Message:    stsErr.Error.Message,
+				RequestID:  stsErr.RequestID,
+			}
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		return CallerIdentity{}, httpRespToErrorResponse(resp, "", "")
+	}
+
+	var identity getCallerIdentityResponse
+	if err = xml.Unmarshal(data, &identity); err != nil {
+		return CallerIdentity{}, err
+	}
+	return identity.Result, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestGetCallerIdentity(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("Action") != "GetCallerIdentity" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.Contains(auth, "/us-east-1/sts/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !strings.Contains(auth, "Credential=accesskey/") || r.Header.Get("X-Amz-Security-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><Error><Type>Sender</Type>`+
+				`<Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error>`+
+				`<RequestId>req-1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><GetCallerIdentityResult>`+
+			`<Arn>arn:aws:sts::123456789012:assumed-role/backup/session</Arn><UserId>AROAEXAMPLE:session</UserId>`+
+			`<Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newClient := func(creds *credentials.Credentials) *Client {
+		clnt, err := New(u.Host, &Options{Creds: creds, Region: "us-east-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return clnt
+	}
+
+	identity, err := newClient(credentials.NewStaticV4("accesskey", "secretkey", "token")).GetCallerIdentity(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CallerIdentity{
+		Account: "123456789012",
+		Arn:     "arn:aws:sts::123456789012:assumed-role/backup/session",
+		UserID:  "AROAEXAMPLE:session",
+	}
+	if identity != want {
+		t.Fatalf("expected %+v, got %+v", want, identity)
+	}
+
+	_, err = newClient(credentials.NewStaticV4("other", "secretkey", "")).GetCallerIdentity(t.Context())
+	if errResp := ToErrorResponse(err); errResp.Code != "InvalidClientTokenId" || errResp.RequestID != "req-1" {
+		t.Fatalf("expected InvalidClientTokenId, got %v", err)
+	}
+
+	if _, err = newClient(credentials.NewStaticV4("", "", "")).GetCallerIdentity(t.Context()); err == nil {
+		t.Fatal("expected error for anonymous credentials")
+	}
+}
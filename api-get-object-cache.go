@@ -0,0 +1,610 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// defaultCacheBlockSize - default size of the blocks objects are cached in.
+const defaultCacheBlockSize = 1024 * 1024
+
+const (
+	cacheMetaFile = "meta.json"
+	cacheDataFile = "data"
+)
+
+// ObjectCacheOptions represents options for the local disk object cache.
+type ObjectCacheOptions struct {
+	// Dir is the directory the cache is persisted in,
+	// entries found in Dir are reused on creation.
+	Dir string
+
+	// MaxSize is the maximum number of cached bytes, least
+	// recently used objects are evicted once it is exceeded.
+	// Zero means unlimited.
+	MaxSize int64
+
+	// BlockSize is the granularity objects are fetched and
+	// cached in, defaults to 1MiB.
+	BlockSize int64
+}
+
+// ObjectCache is a local disk cache for GetObject, FGetObject and
+// StatObject. Cached objects are revalidated on every open with a
+// conditional HEAD request using the stored ETag and are served locally
+// as long as the server answers 304 Not Modified. Object data is fetched
+// lazily in blocks with ranged GET requests, so partially read objects
+// are cached as sparse files.
+//
+// Only VersionID and ServerSideEncryption of GetObjectOptions are honored,
+// cached data is stored unencrypted in files only readable by the owner.
+// Objects encrypted with SSE-C are cached per key, such that they are only
+// served to callers providing the key they were read with.
+type ObjectCache struct {
+	client    *Client
+	dir       string
+	maxSize   int64
+	blockSize int64
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	lru     *list.List // front is the most recently used
+	size    int64
+}
+
+// cacheEntryMeta is the persisted state of a cache entry.
+type cacheEntryMeta struct {
+	Bucket    string     `json:"bucket"`
+	Object    string     `json:"object"`
+	VersionID string     `json:"versionId,omitempty"`
+	BlockSize int64      `json:"blockSize"`
+	Info      ObjectInfo `json:"info"`
+	Blocks    []uint64   `json:"blocks"` // bitset of cached blocks
+}
+
+type cacheEntry struct {
+	path string
+
+	mu   sync.Mutex // protects meta and the data file
+	meta cacheEntryMeta
+
+	// protected by ObjectCache.mu
+	elem   *list.Element
+	refs   int
+	cached int64
+}
+
+// Cache returns a local disk cache for the objects read through the
+// client.
+//
+//	api := client.New(....)
+//	cache, err := api.Cache(minio.ObjectCacheOptions{Dir: "/var/cache/datasets", MaxSize: 100 << 30})
+//	if err != nil {
+//	    log.Fatalln(err)
+//	}
+//	obj, err := cache.GetObject(ctx, "mybucket", "train/shard-0001", minio.GetObjectOptions{})
+func (c *Client) Cache(opts ObjectCacheOptions) (*ObjectCache, error) {
+	if opts.Dir == "" {
+		return nil, errInvalidArgument("Cache directory cannot be empty.")
+	}
+	if opts.MaxSize < 0 || opts.BlockSize < 0 {
+		return nil, errInvalidArgument("Cache size and block size cannot be negative.")
+	}
+	if opts.BlockSize == 0 {
+		opts.BlockSize = defaultCacheBlockSize
+	}
+	if err := os.MkdirAll(opts.Dir, 0o700); err != nil {
+		return nil, err
+	}
+	oc := &ObjectCache{
+		client:    c,
+		dir:       opts.Dir,
+		maxSize:   opts.MaxSize,
+		blockSize: opts.BlockSize,
+		entries:   make(map[string]*cacheEntry),
+		lru:       list.New(),
+	}
+	if err := oc.load(); err != nil {
+		return nil, err
+	}
+	return oc, nil
+}
+
+// load reads the entries persisted in the cache directory, entries are
+// ordered by the last time they were updated.
+func (oc *ObjectCache) load() error {
+	dirEntries, err := os.ReadDir(oc.dir)
+	if err != nil {
+		return err
+	}
+	type loaded struct {
+		id    string
+		entry *cacheEntry
+		mtime int64
+	}
+	var all []loaded
+	for _, de := range dirEntries {
+		if !de.IsDir() {
+			continue
+		}
+		p := filepath.Join(oc.dir, de.Name())
+		fi, err := os.Stat(filepath.Join(p, cacheMetaFile))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(p, cacheMetaFile))
+		if err != nil {
+			continue
+		}
+		entry := &cacheEntry{path: p}
+		if err = json.Unmarshal(data, &entry.meta); err != nil || entry.meta.BlockSize != oc.blockSize {
+			// Unreadable or incompatible entries are dropped.
+			os.RemoveAll(p)
+			continue
+		}
+		entry.cached = entry.cachedBytes()
+		all = append(all, loaded{id: de.Name(), entry: entry, mtime: fi.ModTime().UnixNano()})
+	}
+	slices.SortFunc(all, func(a, b loaded) int {
+		switch {
+		case a.mtime > b.mtime:
+			return -1
+		case a.mtime < b.mtime:
+			return 1
+		}
+		return 0
+	})
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	for _, l := range all {
+		l.entry.elem = oc.lru.PushBack(l.id)
+		oc.entries[l.id] = l.entry
+		oc.size += l.entry.cached
+	}
+	oc.evictLocked()
+	return nil
+}
+
+// Size returns the number of bytes currently cached.
+func (oc *ObjectCache) Size() int64 {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	return oc.size
+}
+
+// GetObject returns a reader for the object, served from the local cache
+// when the cached copy is still current.
+func (oc *ObjectCache) GetObject(ctx context.Context, bucketName, objectName string, opts GetObjectOptions) (*CachedObject, error) {
+	entry, err := oc.open(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(entry.path, cacheDataFile), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		oc.release(entry)
+		return nil, err
+	}
+	entry.mu.Lock()
+	info := entry.meta.Info
+	entry.mu.Unlock()
+	return &CachedObject{
+		ctx:   ctx,
+		cache: oc,
+		entry: entry,
+		info:  info,
+		file:  f,
+		opts:  GetObjectOptions{VersionID: opts.VersionID, ServerSideEncryption: opts.ServerSideEncryption},
+	}, nil
+}
+
+// FGetObject downloads the object to filePath, served from the local cache
+// when the cached copy is still current.
+func (oc *ObjectCache) FGetObject(ctx context.Context, bucketName, objectName, filePath string, opts GetObjectOptions) error {
+	obj, err := oc.GetObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return err
+	}
+	defer obj.Close()
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+		return err
+	}
+	tmpPath := filePath + ".part.minio"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, obj); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// StatObject returns the object information, served from the local cache
+// when the cached copy is still current.
+func (oc *ObjectCache) StatObject(ctx context.Context, bucketName, objectName string, opts StatObjectOptions) (ObjectInfo, error) {
+	entry, err := oc.open(ctx, bucketName, objectName, opts)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer oc.release(entry)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return entry.meta.Info, nil
+}
+
+// open returns the revalidated cache entry for the object, the entry is
+// referenced until released.
+func (oc *ObjectCache) open(ctx context.Context, bucketName, objectName string, opts GetObjectOptions) (*cacheEntry, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectName(objectName); err != nil {
+		return nil, err
+	}
+
+	id := cacheEntryID(bucketName, objectName, opts)
+
+	oc.mu.Lock()
+	entry, ok := oc.entries[id]
+	if !ok {
+		entry = &cacheEntry{
+			path: filepath.Join(oc.dir, id),
+			meta: cacheEntryMeta{
+				Bucket:    bucketName,
+				Object:    objectName,
+				VersionID: opts.VersionID,
+				BlockSize: oc.blockSize,
+			},
+		}
+		entry.elem = oc.lru.PushFront(id)
+		oc.entries[id] = entry
+	} else {
+		oc.lru.MoveToFront(entry.elem)
+	}
+	entry.refs++
+	oc.mu.Unlock()
+
+	if err := oc.revalidate(ctx, entry, opts); err != nil {
+		entry.mu.Lock()
+		unknown := entry.meta.Info.ETag == ""
+		entry.mu.Unlock()
+
+		oc.mu.Lock()
+		entry.refs--
+		// Do not keep entries for objects never seen.
+		if unknown && entry.refs == 0 && oc.entries[id] == entry {
+			oc.lru.Remove(entry.elem)
+			delete(oc.entries, id)
+		}
+		oc.mu.Unlock()
+		return nil, err
+	}
+	return entry, nil
+}
+
+// cacheEntryID returns the name of the cache entry of the object, the
+// SSE-C key is part of it so that cached plaintext is never served to
+// callers without the key.
+func cacheEntryID(bucketName, objectName string, opts GetObjectOptions) string {
+	name := bucketName + "/" + objectName + "?versionId=" + opts.VersionID
+	if sse := opts.ServerSideEncryption; sse != nil && sse.Type() == encrypt.SSEC {
+		h := make(http.Header)
+		sse.Marshal(h)
+		name += "&sse-c=" + h.Get(encrypt.SseCustomerKey)
+	}
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// revalidate checks the cached copy against the server using a
+// conditional request on the stored ETag. Specific versions are
+// immutable and only fetched once.
+func (oc *ObjectCache) revalidate(ctx context.Context, entry *cacheEntry, opts GetObjectOptions) error {
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	known := entry.meta.Info.ETag != ""
+	if known && entry.meta.VersionID != "" {
+		return nil
+	}
+
+	statOpts := StatObjectOptions{VersionID: opts.VersionID, ServerSideEncryption: opts.ServerSideEncryption}
+	if known {
+		statOpts.SetMatchETagExcept(entry.meta.Info.ETag)
+	}
+	info, err := oc.client.StatObject(ctx, entry.meta.Bucket, entry.meta.Object, statOpts)
+	if err != nil {
+		if known && ToErrorResponse(err).StatusCode == http.StatusNotModified {
+			return nil
+		}
+		return err
+	}
+
+	// The object is new or has changed, drop all cached blocks.
+	if err = os.MkdirAll(entry.path, 0o700); err != nil {
+		return err
+	}
+	if err = os.Truncate(filepath.Join(entry.path, cacheDataFile), 0); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	entry.meta.Info = info
+	entry.meta.Blocks = make([]uint64, (oc.numBlocks(info.Size)+63)/64)
+	oc.addCached(entry, -entry.cached)
+	return entry.saveMeta()
+}
+
+// release drops a reference taken by open.
+func (oc *ObjectCache) release(entry *cacheEntry) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	entry.refs--
+	oc.evictLocked()
+}
+
+// addCached accounts delta cached bytes for the entry.
+func (oc *ObjectCache) addCached(entry *cacheEntry, delta int64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	entry.cached += delta
+	oc.size += delta
+	oc.evictLocked()
+}
+
+// evictLocked removes least recently used entries which are not in use
+// until the cache fits into its maximum size.
+func (oc *ObjectCache) evictLocked() {
+	if oc.maxSize <= 0 {
+		return
+	}
+	for elem := oc.lru.Back(); elem != nil && oc.size > oc.maxSize; {
+		prev := elem.Prev()
+		id := elem.Value.(string)
+		if entry := oc.entries[id]; entry.refs == 0 {
+			os.RemoveAll(entry.path)
+			oc.lru.Remove(elem)
+			delete(oc.entries, id)
+			oc.size -= entry.cached
+		}
+		elem = prev
+	}
+}
+
+func (oc *ObjectCache) numBlocks(size int64) int64 {
+	return (size + oc.blockSize - 1) / oc.blockSize
+}
+
+// hasBlock returns true if block i is cached.
+func (e *cacheEntry) hasBlock(i int64) bool {
+	return e.meta.Blocks[i/64]&(1<<(i%64)) != 0
+}
+
+// blockSize returns the size of block i.
+func (e *cacheEntry) blockSize(i int64) int64 {
+	return min(e.meta.BlockSize, e.meta.Info.Size-i*e.meta.BlockSize)
+}
+
+// cachedBytes returns the number of bytes cached for the entry.
+func (e *cacheEntry) cachedBytes() int64 {
+	var n int64
+	for i := range (e.meta.Info.Size + e.meta.BlockSize - 1) / e.meta.BlockSize {
+		if int(i/64) < len(e.meta.Blocks) && e.hasBlock(i) {
+			n += e.blockSize(i)
+		}
+	}
+	return n
+}
+
+// saveMeta persists the entry metadata atomically.
+func (e *cacheEntry) saveMeta() error {
+	data, err := json.Marshal(e.meta)
+	if err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(e.path, cacheMetaFile+".tmp")
+	if err = os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(e.path, cacheMetaFile))
+}
+
+// CachedObject is a reader for an object served through an ObjectCache, it
+// implements io.Reader, io.ReaderAt, io.Seeker and io.Closer.
+//
+// If the object changes on the server and the cache entry is revalidated
+// by another open while the reader is in use, reads fail with
+// PreconditionFailed rather than returning data of both versions.
+type CachedObject struct {
+	ctx    context.Context
+	cache  *ObjectCache
+	entry  *cacheEntry
+	info   ObjectInfo // the object as it was opened
+	file   *os.File
+	opts   GetObjectOptions
+	offset int64
+	closed bool
+	mu     sync.Mutex
+}
+
+// Stat returns the information of the cached object.
+func (o *CachedObject) Stat() (ObjectInfo, error) {
+	return o.info, nil
+}
+
+// Read reads up to len(b) bytes at the current offset.
+func (o *CachedObject) Read(b []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n, err := o.readAt(b, o.offset)
+	o.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// ReadAt reads len(b) bytes at offset, fetching missing blocks from the
+// server.
+func (o *CachedObject) ReadAt(b []byte, offset int64) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.readAt(b, offset)
+}
+
+// Seek sets the offset for the next Read.
+func (o *CachedObject) Seek(offset int64, whence int) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return 0, errInvalidArgument("Object is already closed")
+	}
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.info.Size
+	default:
+		return 0, errInvalidArgument("Invalid whence")
+	}
+	if offset < 0 {
+		return 0, errInvalidArgument("Negative position not allowed")
+	}
+	o.offset = offset
+	return offset, nil
+}
+
+// Close releases the cache entry.
+func (o *CachedObject) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return errInvalidArgument("Object is already closed")
+	}
+	o.closed = true
+	err := o.file.Close()
+	o.cache.release(o.entry)
+	return err
+}
+
+func (o *CachedObject) readAt(b []byte, offset int64) (int, error) {
+	if o.closed {
+		return 0, errInvalidArgument("Object is already closed")
+	}
+	if offset < 0 {
+		return 0, errInvalidArgument("Negative position not allowed")
+	}
+
+	e := o.entry
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// The cached blocks were dropped when the object changed.
+	if e.meta.Info.ETag != o.info.ETag {
+		return 0, ErrorResponse{
+			StatusCode: http.StatusPreconditionFailed,
+			Code:       PreconditionFailed,
+			Message:    s3ErrorResponseMap[PreconditionFailed],
+			BucketName: e.meta.Bucket,
+			Key:        e.meta.Object,
+		}
+	}
+
+	size := e.meta.Info.Size
+	if offset >= size {
+		return 0, io.EOF
+	}
+	n := min(int64(len(b)), size-offset)
+	if err := o.fill(offset/e.meta.BlockSize, (offset+n-1)/e.meta.BlockSize); err != nil {
+		return 0, err
+	}
+	m, err := o.file.ReadAt(b[:n], offset)
+	if err == nil && n < int64(len(b)) {
+		err = io.EOF
+	}
+	return m, err
+}
+
+// fill fetches the missing blocks between first and last, contiguous
+// missing blocks are fetched with a single ranged request conditional on
+// the cached ETag. Must be called with the entry locked.
+func (o *CachedObject) fill(first, last int64) error {
+	e := o.entry
+	for i := first; i <= last; i++ {
+		if e.hasBlock(i) {
+			continue
+		}
+		j := i
+		for j < last && !e.hasBlock(j+1) {
+			j++
+		}
+
+		start := i * e.meta.BlockSize
+		end := j*e.meta.BlockSize + e.blockSize(j) - 1
+		opts := o.opts
+		opts.SetRange(start, end)
+		opts.SetMatchETag(e.meta.Info.ETag)
+		reader, _, _, err := o.cache.client.getObject(o.ctx, e.meta.Bucket, e.meta.Object, opts)
+		if err != nil {
+			return err
+		}
+		copied, err := io.Copy(io.NewOffsetWriter(o.file, start), io.LimitReader(reader, end-start+1))
+		reader.Close()
+		if err != nil {
+			return err
+		}
+		if copied != end-start+1 {
+			return io.ErrUnexpectedEOF
+		}
+
+		var added int64
+		for k := i; k <= j; k++ {
+			e.meta.Blocks[k/64] |= 1 << (k % 64)
+			added += e.blockSize(k)
+		}
+		if err = e.saveMeta(); err != nil {
+			return err
+		}
+		o.cache.addCached(e, added)
+		i = j
+	}
+	return nil
+}
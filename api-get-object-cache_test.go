@@ -0,0 +1,246 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+func TestObjectCache(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		content = map[string]string{
+			"data.bin":  strings.Repeat("a", 10) + strings.Repeat("b", 10) + strings.Repeat("c", 5),
+			"other.bin": strings.Repeat("x", 20),
+		}
+		etags = map[string]string{"data.bin": "v1", "other.bin": "o1"}
+		gets  int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := strings.TrimPrefix(r.URL.Path, "/test-bucket/")
+		data, ok := content[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		w.Header().Set("ETag", `"`+etags[key]+`"`)
+		http.ServeContent(w, r, key, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), strings.NewReader(data))
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Secure: false,
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	cache, err := client.Cache(ObjectCacheOptions{Dir: dir, BlockSize: 10, MaxSize: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readAt := func(off, n int64) string {
+		t.Helper()
+		obj, err := cache.GetObject(t.Context(), "test-bucket", "data.bin", GetObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer obj.Close()
+		buf := make([]byte, n)
+		m, err := obj.ReadAt(buf, off)
+		if err != nil && err != io.EOF {
+			t.Fatal(err)
+		}
+		return string(buf[:m])
+	}
+
+	// Partial read only fetches the middle block.
+	if got := readAt(12, 3); got != "bbb" {
+		t.Fatalf("unexpected content %q", got)
+	}
+	if gets != 1 || cache.Size() != 10 {
+		t.Fatalf("expected 1 GET and 10 cached bytes, got %d and %d", gets, cache.Size())
+	}
+
+	// Cached block is served locally after revalidation.
+	if got := readAt(10, 10); got != strings.Repeat("b", 10) {
+		t.Fatalf("unexpected content %q", got)
+	}
+	if gets != 1 {
+		t.Fatalf("expected cached read, got %d GETs", gets)
+	}
+
+	// Reading everything fetches the missing blocks.
+	if got := readAt(0, 30); got != content["data.bin"] {
+		t.Fatalf("unexpected content %q", got)
+	}
+	if gets != 3 || cache.Size() != 25 {
+		t.Fatalf("expected 3 GETs and 25 cached bytes, got %d and %d", gets, cache.Size())
+	}
+
+	// FGetObject is served from the cache.
+	filePath := filepath.Join(t.TempDir(), "out", "data.bin")
+	if err = cache.FGetObject(t.Context(), "test-bucket", "data.bin", filePath, GetObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filePath); string(data) != content["data.bin"] {
+		t.Fatalf("unexpected file content %q", data)
+	}
+	if gets != 3 {
+		t.Fatalf("expected cached download, got %d GETs", gets)
+	}
+
+	// A changed object invalidates the cached blocks, readers of the old
+	// version fail instead of reading the new blocks.
+	stale, err := cache.GetObject(t.Context(), "test-bucket", "data.bin", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	content["data.bin"] = strings.Repeat("z", 15)
+	etags["data.bin"] = "v2"
+	mu.Unlock()
+	info, err := cache.StatObject(t.Context(), "test-bucket", "data.bin", StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ETag != "v2" || info.Size != 15 || cache.Size() != 0 {
+		t.Fatalf("expected invalidated entry, got %+v with %d cached bytes", info, cache.Size())
+	}
+	if got := readAt(0, 15); got != strings.Repeat("z", 15) {
+		t.Fatalf("unexpected content %q", got)
+	}
+	if _, err = stale.ReadAt(make([]byte, 5), 0); ToErrorResponse(err).Code != PreconditionFailed {
+		t.Fatalf("expected PreconditionFailed reading a changed object, got %v", err)
+	}
+	if info, _ = stale.Stat(); info.ETag != "v1" {
+		t.Fatalf("expected the opened version, got %+v", info)
+	}
+	stale.Close()
+
+	// Exceeding the size limit evicts the least recently used object.
+	obj, err := cache.GetObject(t.Context(), "test-bucket", "other.bin", GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadAll(obj); err != nil {
+		t.Fatal(err)
+	}
+	obj.Close()
+	if cache.Size() != 20 {
+		t.Fatalf("expected data.bin to be evicted, got %d cached bytes", cache.Size())
+	}
+
+	// Entries are reused across cache instances.
+	reopened, err := client.Cache(ObjectCacheOptions{Dir: dir, BlockSize: 10, MaxSize: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Size() != 20 {
+		t.Fatalf("expected 20 cached bytes after reopen, got %d", reopened.Size())
+	}
+
+	if _, err = cache.GetObject(t.Context(), "test-bucket", "missing", GetObjectOptions{}); ToErrorResponse(err).Code != NoSuchKey {
+		t.Fatalf("expected NoSuchKey, got %v", err)
+	}
+}
+
+func TestObjectCacheSSEC(t *testing.T) {
+	key, err := encrypt.NewSSEC([]byte("my-secret-key1234567890abcdefghi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := encrypt.NewSSEC([]byte("other-secret-key1234567890abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := make(http.Header)
+	key.Marshal(h)
+	customerKey := h.Get(encrypt.SseCustomerKey)
+
+	const data = "secret data"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(encrypt.SseCustomerKey) != customerKey {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("X-Amz-Version-Id", "v1")
+		http.ServeContent(w, r, "secret.bin", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), strings.NewReader(data))
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := client.Cache(ObjectCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := cache.GetObject(t.Context(), "test-bucket", "secret.bin", GetObjectOptions{VersionID: "v1", ServerSideEncryption: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(obj)
+	obj.Close()
+	if err != nil || string(got) != data {
+		t.Fatalf("unexpected content %q, %v", got, err)
+	}
+
+	// The cached plaintext is not served without the key, or with another
+	// key.
+	for _, sse := range []encrypt.ServerSide{nil, other} {
+		if _, err = cache.GetObject(t.Context(), "test-bucket", "secret.bin", GetObjectOptions{VersionID: "v1", ServerSideEncryption: sse}); err == nil {
+			t.Fatalf("expected the cached version not to be served with %v", sse)
+		}
+	}
+}
@@ -46,6 +46,14 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
+	if opts.VersionID == "" && !opts.AsOf.IsZero() {
+		objInfo, err := c.objectVersionAsOf(ctx, bucketName, objectName, opts.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		opts.VersionID = objInfo.VersionID
+	}
+
 	gctx, cancel := context.WithCancel(ctx)
 
 	// Detect if snowball is server location we are talking to.
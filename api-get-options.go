@@ -43,6 +43,11 @@ type GetObjectOptions struct {
 	VersionID            string
 	PartNumber           int
 
+	// AsOf resolves VersionID to the version of the object that was
+	// live at the given instant on a versioned bucket. It is ignored
+	// when VersionID is set.
+	AsOf time.Time
+
 	// Include any checksums, if object was uploaded with checksum.
 	// For multipart objects this is a checksum of part checksums.
 	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html
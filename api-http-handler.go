@@ -0,0 +1,309 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// BucketHandlerOptions represents options for the bucket http.Handler.
+type BucketHandlerOptions struct {
+	// Prefix is prepended to the request path to
+	// build the object name.
+	Prefix string
+
+	// IndexDocument is served for request paths ending
+	// in "/", for example "index.html". Requests for a
+	// path without trailing "/" are redirected when
+	// only the index document below it exists.
+	IndexDocument string
+
+	// ErrorDocument is served with status 404 when
+	// the requested object does not exist.
+	ErrorDocument string
+
+	// CacheControl is the Cache-Control value used for
+	// objects without Cache-Control metadata.
+	CacheControl string
+
+	// ServerSideEncryption is used to read SSE-C
+	// encrypted objects.
+	ServerSideEncryption encrypt.ServerSide
+}
+
+// BucketHandler is an http.Handler serving the objects of a bucket like a
+// static website. It supports GET and HEAD, single range requests and
+// conditional requests, which are passed on to the server so that 206,
+// 304 and 412 responses are produced by the object store.
+type BucketHandler struct {
+	client     *Client
+	bucketName string
+	opts       BucketHandlerOptions
+}
+
+// Handler returns an http.Handler serving the objects of bucketName.
+//
+//	api := client.New(....)
+//	h, err := api.Handler("mybucket", minio.BucketHandlerOptions{IndexDocument: "index.html"})
+//	if err != nil {
+//	    log.Fatalln(err)
+//	}
+//	http.ListenAndServe(":8080", h)
+func (c *Client) Handler(bucketName string, opts BucketHandlerOptions) (*BucketHandler, error) {
+	if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+		return nil, err
+	}
+	if err := s3utils.CheckValidObjectNamePrefix(opts.Prefix); err != nil {
+		return nil, err
+	}
+	return &BucketHandler{
+		client:     c,
+		bucketName: bucketName,
+		opts:       opts,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *BucketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name != "" {
+		dir := strings.HasSuffix(name, "/")
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		if dir && name != "" {
+			name += "/"
+		}
+	}
+	indexed := name == "" || strings.HasSuffix(name, "/")
+	if indexed {
+		if h.opts.IndexDocument == "" {
+			h.serveError(w, r, ErrorResponse{StatusCode: http.StatusNotFound, Code: NoSuchKey})
+			return
+		}
+		name += h.opts.IndexDocument
+	}
+
+	opts, err := h.requestOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.serveObject(w, r, h.opts.Prefix+name, opts, http.StatusOK)
+	if err == nil {
+		return
+	}
+	if ToErrorResponse(err).StatusCode == http.StatusNotModified {
+		h.serveNotModified(w, r, h.opts.Prefix+name)
+		return
+	}
+	if isNotExist(err) && !indexed && h.opts.IndexDocument != "" {
+		// Redirect "dir" to "dir/" if it has an index document. The
+		// location is built from the cleaned name, such that a path
+		// like "//host" does not redirect to another host.
+		_, serr := h.client.StatObject(r.Context(), h.bucketName, h.opts.Prefix+name+"/"+h.opts.IndexDocument, h.getOptions())
+		if serr == nil {
+			location := &url.URL{Path: "/" + name + "/", RawQuery: r.URL.RawQuery}
+			http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+			return
+		}
+	}
+	h.serveError(w, r, err)
+}
+
+// serveNotModified writes a 304 response with the validators and caching
+// headers of the object, as required by RFC 7232.
+func (h *BucketHandler) serveNotModified(w http.ResponseWriter, r *http.Request, objectName string) {
+	if info, err := h.client.StatObject(r.Context(), h.bucketName, objectName, h.getOptions()); err == nil {
+		h.setCacheHeaders(w.Header(), info)
+	}
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// getOptions returns the options needed to access any object.
+func (h *BucketHandler) getOptions() GetObjectOptions {
+	return GetObjectOptions{ServerSideEncryption: h.opts.ServerSideEncryption}
+}
+
+// requestOptions maps the conditional and range request headers to
+// GetObjectOptions.
+func (h *BucketHandler) requestOptions(r *http.Request) (GetObjectOptions, error) {
+	opts := h.getOptions()
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(strings.TrimSpace(inm), "W/")
+		if strings.ContainsAny(etag, ",*") {
+			opts.Set("If-None-Match", inm)
+		} else if err := opts.SetMatchETagExcept(trimEtag(etag)); err != nil {
+			return opts, err
+		}
+	} else if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		// If-Modified-Since is ignored when If-None-Match is present.
+		if t, err := http.ParseTime(ims); err == nil {
+			opts.SetModified(t)
+		}
+	}
+
+	// Only single ranges are supported, a Range combined with
+	// If-Range is served as a full response which is allowed
+	// by RFC 7233.
+	rng := r.Header.Get("Range")
+	if r.Method != http.MethodGet || rng == "" || r.Header.Get("If-Range") != "" {
+		return opts, nil
+	}
+	spec, ok := strings.CutPrefix(rng, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return opts, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return opts, nil
+	}
+	var start, end int64
+	var err error
+	switch {
+	case first == "":
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end <= 0 {
+			return opts, nil
+		}
+		end = -end
+	case last == "":
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil || start <= 0 {
+			return opts, nil
+		}
+	default:
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil {
+			return opts, nil
+		}
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil {
+			return opts, nil
+		}
+	}
+	// Invalid ranges are ignored and served in full.
+	opts.SetRange(start, end)
+	return opts, nil
+}
+
+// serveObject writes objectName to w with the given status code, ranged
+// responses are always written with status 206.
+func (h *BucketHandler) serveObject(w http.ResponseWriter, r *http.Request, objectName string, opts GetObjectOptions, statusCode int) error {
+	if r.Method == http.MethodHead {
+		info, err := h.client.StatObject(r.Context(), h.bucketName, objectName, opts)
+		if err != nil {
+			return err
+		}
+		if h.redirect(w, r, info) {
+			return nil
+		}
+		h.setHeaders(w.Header(), info)
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+		w.WriteHeader(statusCode)
+		return nil
+	}
+
+	reader, info, header, err := h.client.getObject(r.Context(), h.bucketName, objectName, opts)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if h.redirect(w, r, info) {
+		return nil
+	}
+	h.setHeaders(w.Header(), info)
+	w.Header().Set("Content-Length", header.Get("Content-Length"))
+	if contentRange := header.Get("Content-Range"); contentRange != "" {
+		w.Header().Set("Content-Range", contentRange)
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+	io.Copy(w, reader)
+	return nil
+}
+
+// redirect serves website redirects configured on the object.
+func (h *BucketHandler) redirect(w http.ResponseWriter, r *http.Request, info ObjectInfo) bool {
+	location := info.Metadata.Get("X-Amz-Website-Redirect-Location")
+	if location == "" {
+		return false
+	}
+	http.Redirect(w, r, location, http.StatusMovedPermanently)
+	return true
+}
+
+// setHeaders sets the representation headers of the object.
+func (h *BucketHandler) setHeaders(header http.Header, info ObjectInfo) {
+	header.Set("Accept-Ranges", "bytes")
+	header.Set("Content-Type", info.ContentType)
+	for _, k := range []string{"Content-Encoding", "Content-Language", "Content-Disposition"} {
+		if v := info.Metadata.Get(k); v != "" {
+			header.Set(k, v)
+		}
+	}
+	h.setCacheHeaders(header, info)
+}
+
+// setCacheHeaders sets the validators and caching headers of the object.
+func (h *BucketHandler) setCacheHeaders(header http.Header, info ObjectInfo) {
+	if info.ETag != "" {
+		header.Set("ETag", "\""+info.ETag+"\"")
+	}
+	if !info.LastModified.IsZero() {
+		header.Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if !info.Expires.IsZero() {
+		header.Set("Expires", info.Expires.UTC().Format(http.TimeFormat))
+	}
+	if v := info.Metadata.Get("Cache-Control"); v != "" {
+		header.Set("Cache-Control", v)
+	} else if h.opts.CacheControl != "" {
+		header.Set("Cache-Control", h.opts.CacheControl)
+	}
+}
+
+// serveError writes the status matching err, missing objects are answered
+// with the error document if one is configured.
+func (h *BucketHandler) serveError(w http.ResponseWriter, r *http.Request, err error) {
+	errResp := ToErrorResponse(err)
+	switch {
+	case isNotExist(err):
+		if h.opts.ErrorDocument != "" {
+			if h.serveObject(w, r, h.opts.Prefix+h.opts.ErrorDocument, h.getOptions(), http.StatusNotFound) == nil {
+				return
+			}
+		}
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	case errResp.StatusCode >= http.StatusBadRequest:
+		http.Error(w, http.StatusText(errResp.StatusCode), errResp.StatusCode)
+	default:
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBucketHandler(t *testing.T) {
+	client := newFSTestServer(t, "test-bucket", map[string]string{
+		"www/index.html":              "<html>index</html>",
+		"www/404.html":                "not found",
+		"www/docs/index.html":         "docs",
+		"www/evil.example/index.html": "evil",
+		"www/data.txt":                "0123456789",
+	})
+
+	h, err := client.Handler("test-bucket", BucketHandlerOptions{
+		Prefix:        "www/",
+		IndexDocument: "index.html",
+		ErrorDocument: "404.html",
+		CacheControl:  "max-age=60",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method     string
+		path       string
+		header     http.Header
+		statusCode int
+		body       string
+		location   string
+	}{
+		{http.MethodGet, "/", nil, http.StatusOK, "<html>index</html>", ""},
+		{http.MethodGet, "/docs/", nil, http.StatusOK, "docs", ""},
+		{http.MethodGet, "/docs", nil, http.StatusMovedPermanently, "", "/docs/"},
+		{http.MethodGet, "/docs?lang=en", nil, http.StatusMovedPermanently, "", "/docs/?lang=en"},
+		{http.MethodGet, "//evil.example", nil, http.StatusMovedPermanently, "", "/evil.example/"},
+		{http.MethodGet, "/missing", nil, http.StatusNotFound, "not found", ""},
+		{http.MethodGet, "/data.txt", http.Header{"Range": {"bytes=2-5"}}, http.StatusPartialContent, "2345", ""},
+		{http.MethodGet, "/data.txt", http.Header{"Range": {"bytes=-3"}}, http.StatusPartialContent, "789", ""},
+		{http.MethodGet, "/data.txt", http.Header{"Range": {"bytes=0-1,4-5"}}, http.StatusOK, "0123456789", ""},
+		{http.MethodGet, "/data.txt", http.Header{"If-None-Match": {`"www/data.txt"`}}, http.StatusNotModified, "", ""},
+		{http.MethodGet, "/data.txt", http.Header{"If-None-Match": {`"other"`}}, http.StatusOK, "0123456789", ""},
+		{http.MethodGet, "/data.txt", http.Header{"If-Modified-Since": {"Tue, 02 Jan 2024 00:00:00 GMT"}}, http.StatusNotModified, "", ""},
+		{http.MethodHead, "/data.txt", nil, http.StatusOK, "", ""},
+		{http.MethodPut, "/data.txt", nil, http.StatusMethodNotAllowed, "Method Not Allowed\n", ""},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.path, nil)
+		for k, v := range testCase.header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != testCase.statusCode {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.statusCode, rec.Code)
+			continue
+		}
+		if testCase.location != "" && rec.Header().Get("Location") != testCase.location {
+			t.Errorf("Test %d: expected location %q, got %q", i+1, testCase.location, rec.Header().Get("Location"))
+		}
+		if testCase.location == "" && rec.Body.String() != testCase.body {
+			t.Errorf("Test %d: expected body %q, got %q", i+1, testCase.body, rec.Body.String())
+		}
+		if rec.Code == http.StatusNotModified && (rec.Header().Get("ETag") != `"www/data.txt"` || rec.Header().Get("Last-Modified") == "") {
+			t.Errorf("Test %d: expected validators, got ETag %q, Last-Modified %q", i+1, rec.Header().Get("ETag"), rec.Header().Get("Last-Modified"))
+		}
+		if (rec.Code == http.StatusOK || rec.Code == http.StatusNotModified) && rec.Header().Get("Cache-Control") != "max-age=60" {
+			t.Errorf("Test %d: expected default Cache-Control, got %q", i+1, rec.Header().Get("Cache-Control"))
+		}
+	}
+}
@@ -0,0 +1,361 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// LifecycleDryRunResult is a lifecycle action LifecycleDryRun found for an
+// object version or incomplete multipart upload.
+type LifecycleDryRunResult struct {
+	// Key, VersionID and UploadID identify the object version or
+	// incomplete multipart upload.
+	Key       string
+	VersionID string
+	UploadID  string
+
+	// Size is the number of bytes the action applies to, the size of all
+	// versions of the object for actions deleting all versions.
+	Size           int64
+	IsLatest       bool
+	IsDeleteMarker bool
+
+	// Event is the action and the rule it is from.
+	lifecycle.Event
+
+	// Totals per rule and action, only set in the last result after the
+	// whole bucket was walked.
+	Totals []LifecycleRuleTotal
+
+	// Err ends the results, the walk failed.
+	Err error
+}
+
+// LifecycleRuleTotal is the number of versions and bytes a lifecycle rule
+// applies an action to.
+type LifecycleRuleTotal struct {
+	RuleID       string
+	Action       lifecycle.Action
+	StorageClass string
+	Objects      int64
+	Bytes        int64
+}
+
+// LifecycleDryRun walks all object versions and incomplete multipart
+// uploads of a bucket and reports which of them the lifecycle configuration
+// would expire, transition or abort at the given time, without changing
+// anything. The bucket's own configuration is evaluated if cfg is nil, and
+// the current time if at is zero.
+//
+// Filters by prefix, tags and size and the actions of the rules are applied
+// as by lifecycle.Configuration.Eval. If an enabled rule filters by tags and
+// the server does not list the tags of objects, such as AWS S3, the tags are
+// read with one GetObjectTagging request per object version. Pass a
+// configuration without such rules to avoid that cost. The last result
+// holds the totals per rule, or an error.
+//
+//	api := client.New(....)
+//	for result := range api.LifecycleDryRun(ctx, "mytestbucket", cfg, time.Now().AddDate(0, 0, 30)) {
+//	    if result.Err != nil {
+//	        // handle the error.
+//	    }
+//	    fmt.Println(result)
+//	}
+//
+// The caller must drain the channel until it is closed, canceling the
+// context ends the walk.
+func (c *Client) LifecycleDryRun(ctx context.Context, bucketName string, cfg *lifecycle.Configuration, at time.Time) <-chan LifecycleDryRunResult {
+	resultCh := make(chan LifecycleDryRunResult, 1)
+	go func() {
+		defer close(resultCh)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		d := lifecycleDryRun{
+			c:          c,
+			bucketName: bucketName,
+			cfg:        cfg,
+			at:         at,
+			resultCh:   resultCh,
+			totals:     make(map[LifecycleRuleTotal]*LifecycleRuleTotal),
+		}
+		var result LifecycleDryRunResult
+		if err := d.run(ctx); err != nil {
+			result = LifecycleDryRunResult{Err: err}
+		} else {
+			result.Totals = d.sortedTotals()
+		}
+		select {
+		case <-ctx.Done():
+		case resultCh <- result:
+		}
+	}()
+	return resultCh
+}
+
+// lifecycleDryRun is the state of a LifecycleDryRun walk.
+type lifecycleDryRun struct {
+	c          *Client
+	bucketName string
+	cfg        *lifecycle.Configuration
+	at         time.Time
+	resultCh   chan<- LifecycleDryRunResult
+
+	// needsTags is set if rules filter by tags.
+	needsTags bool
+	// transitionClasses are the storage classes objects are transitioned
+	// to, objects in them were transitioned already.
+	transitionClasses map[string]bool
+
+	// totals are keyed by rule, action and storage class.
+	totals map[LifecycleRuleTotal]*LifecycleRuleTotal
+}
+
+func (d *lifecycleDryRun) run(ctx context.Context) error {
+	if err := s3utils.CheckValidBucketName(d.bucketName); err != nil {
+		return err
+	}
+	if d.cfg == nil {
+		cfg, err := d.c.GetBucketLifecycle(ctx, d.bucketName)
+		if err != nil {
+			return err
+		}
+		d.cfg = cfg
+	}
+	if d.at.IsZero() {
+		d.at = time.Now().UTC()
+	}
+
+	var (
+		prefixes      []string
+		abortsUploads bool
+		enabled       bool
+	)
+	d.transitionClasses = make(map[string]bool)
+	for _, rule := range d.cfg.Rules {
+		if rule.Status != "Enabled" {
+			continue
+		}
+		prefixes = append(prefixes, rule.FilterPrefix())
+		if !rule.RuleFilter.Tag.IsEmpty() || len(rule.RuleFilter.And.Tags) > 0 {
+			d.needsTags = true
+		}
+		if !rule.Transition.IsNull() {
+			d.transitionClasses[rule.Transition.StorageClass] = true
+		}
+		if !rule.NoncurrentVersionTransition.IsStorageClassEmpty() {
+			d.transitionClasses[rule.NoncurrentVersionTransition.StorageClass] = true
+		}
+		if !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			abortsUploads = true
+		}
+		enabled = true
+	}
+	if !enabled {
+		return nil
+	}
+	prefix := commonPrefix(prefixes)
+
+	var versions []ObjectInfo
+	for obj := range d.c.listObjectVersions(ctx, d.bucketName, ListObjectsOptions{
+		WithVersions: true,
+		WithMetadata: d.needsTags,
+		Recursive:    true,
+		Prefix:       prefix,
+	}) {
+		if obj.Err != nil {
+			return obj.Err
+		}
+		if len(versions) > 0 && obj.Key != versions[0].Key {
+			if err := d.evalVersions(ctx, versions); err != nil {
+				return err
+			}
+			versions = versions[:0]
+		}
+		versions = append(versions, obj)
+	}
+	if len(versions) > 0 {
+		if err := d.evalVersions(ctx, versions); err != nil {
+			return err
+		}
+	}
+
+	if abortsUploads {
+		for upload := range d.c.listIncompleteUploads(ctx, d.bucketName, prefix, true) {
+			if upload.Err != nil {
+				return upload.Err
+			}
+			event := d.cfg.Eval(lifecycle.ObjectAttributes{
+				Key:              upload.Key,
+				ModTime:          upload.Initiated,
+				IncompleteUpload: true,
+			}, d.at)
+			if event.Action == lifecycle.NoneAction {
+				continue
+			}
+			// Uploads are listed without their size, which is the size of
+			// the parts uploaded so far.
+			parts, err := d.c.listObjectParts(ctx, d.bucketName, upload.Key, upload.UploadID)
+			if err != nil {
+				return err
+			}
+			var size int64
+			for _, part := range parts {
+				size += part.Size
+			}
+			if err := d.send(ctx, LifecycleDryRunResult{
+				Key:      upload.Key,
+				UploadID: upload.UploadID,
+				Size:     size,
+				Event:    event,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// evalVersions evaluates the versions of an object, newest first.
+func (d *lifecycleDryRun) evalVersions(ctx context.Context, versions []ObjectInfo) error {
+	for i, v := range versions {
+		attrs := lifecycle.ObjectAttributes{
+			Key:          v.Key,
+			Tags:         v.UserTags,
+			Size:         v.Size,
+			ModTime:      v.LastModified,
+			IsLatest:     v.IsLatest,
+			DeleteMarker: v.IsDeleteMarker,
+			NumVersions:  len(versions),
+			Transitioned: d.transitionClasses[v.StorageClass],
+		}
+		if !v.IsLatest && i > 0 {
+			attrs.NoncurrentSince = versions[i-1].LastModified
+			attrs.NewerNoncurrentVersions = i - 1
+		}
+		// Servers listing metadata list the tags too.
+		if d.needsTags && !v.IsDeleteMarker && v.UserTags == nil && v.UserMetadata == nil {
+			t, err := d.c.GetObjectTagging(ctx, d.bucketName, v.Key, GetObjectTaggingOptions{VersionID: v.VersionID})
+			if err != nil {
+				return err
+			}
+			attrs.Tags = t.ToMap()
+		}
+
+		event := d.cfg.Eval(attrs, d.at)
+		if event.Action == lifecycle.NoneAction {
+			continue
+		}
+		result := LifecycleDryRunResult{
+			Key:            v.Key,
+			VersionID:      v.VersionID,
+			Size:           v.Size,
+			IsLatest:       v.IsLatest,
+			IsDeleteMarker: v.IsDeleteMarker,
+			Event:          event,
+		}
+		deletesAll := event.Action == lifecycle.DeleteAllVersionsAction || event.Action == lifecycle.DelMarkerDeleteAllVersionsAction
+		if deletesAll {
+			result.Size = 0
+			for _, v := range versions {
+				result.Size += v.Size
+			}
+		}
+		if err := d.send(ctx, result); err != nil {
+			return err
+		}
+		// The other versions are deleted along with the latest one.
+		if deletesAll {
+			return nil
+		}
+	}
+	return nil
+}
+
+// send streams a result and adds it to the totals.
+func (d *lifecycleDryRun) send(ctx context.Context, result LifecycleDryRunResult) error {
+	key := LifecycleRuleTotal{
+		RuleID:       result.RuleID,
+		Action:       result.Action,
+		StorageClass: result.StorageClass,
+	}
+	total, ok := d.totals[key]
+	if !ok {
+		total = &key
+		d.totals[key] = total
+	}
+	total.Objects++
+	total.Bytes += result.Size
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case d.resultCh <- result:
+		return nil
+	}
+}
+
+// sortedTotals returns the totals sorted by rule, action and storage
+// class.
+func (d *lifecycleDryRun) sortedTotals() []LifecycleRuleTotal {
+	totals := make([]LifecycleRuleTotal, 0, len(d.totals))
+	for _, total := range d.totals {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		a, b := totals[i], totals[j]
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.StorageClass < b.StorageClass
+	})
+	return totals
+}
+
+// commonPrefix returns the longest common prefix of prefixes, which does
+// not end in a partial UTF-8 character.
+func commonPrefix(prefixes []string) string {
+	if len(prefixes) == 0 {
+		return ""
+	}
+	prefix := prefixes[0]
+	for _, p := range prefixes[1:] {
+		for !strings.HasPrefix(p, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for len(prefix) > 0 {
+		if r, size := utf8.DecodeLastRuneInString(prefix); r != utf8.RuneError || size > 1 {
+			break
+		}
+		prefix = prefix[:len(prefix)-1]
+	}
+	return prefix
+}
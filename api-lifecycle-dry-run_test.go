@@ -0,0 +1,152 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestLifecycleDryRun(t *testing.T) {
+	const versions = `<ListVersionsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Name>test-bucket</Name><IsTruncated>false</IsTruncated>
+<Version><Key>data/x</Key><VersionId>x2</VersionId><IsLatest>true</IsLatest><LastModified>2026-05-01T00:00:00.000Z</LastModified><ETag>"x2"</ETag><Size>5</Size></Version>
+<Version><Key>data/x</Key><VersionId>x1</VersionId><IsLatest>false</IsLatest><LastModified>2026-04-01T00:00:00.000Z</LastModified><ETag>"x1"</ETag><Size>7</Size></Version>
+<DeleteMarker><Key>data/y</Key><VersionId>y1</VersionId><IsLatest>true</IsLatest><LastModified>2026-05-30T00:00:00.000Z</LastModified></DeleteMarker>
+<Version><Key>logs/a.log</Key><VersionId>a1</VersionId><IsLatest>true</IsLatest><LastModified>2026-04-01T00:00:00.000Z</LastModified><ETag>"a1"</ETag><Size>100</Size></Version>
+<Version><Key>logs/b.log</Key><VersionId>b1</VersionId><IsLatest>true</IsLatest><LastModified>2026-05-20T00:00:00.000Z</LastModified><ETag>"b1"</ETag><Size>50</Size></Version>
+<Version><Key>logs/c.log</Key><VersionId>c1</VersionId><IsLatest>true</IsLatest><LastModified>2026-05-31T00:00:00.000Z</LastModified><ETag>"c1"</ETag><Size>10</Size></Version>
+<Version><Key>logs/d.log</Key><VersionId>d1</VersionId><IsLatest>true</IsLatest><LastModified>2026-05-10T00:00:00.000Z</LastModified><ETag>"d1"</ETag><Size>20</Size><StorageClass>WARM</StorageClass></Version>
+</ListVersionsResult>`
+	const uploads = `<ListMultipartUploadsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Bucket>test-bucket</Bucket><IsTruncated>false</IsTruncated>
+<Upload><Key>data/z</Key><UploadId>z1</UploadId><Initiated>2026-05-01T00:00:00.000Z</Initiated></Upload>
+<Upload><Key>data/w</Key><UploadId>w1</UploadId><Initiated>2026-05-31T00:00:00.000Z</Initiated></Upload>
+</ListMultipartUploadsResult>`
+	const parts = `<ListPartsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Bucket>test-bucket</Bucket><Key>data/z</Key><UploadId>z1</UploadId><IsTruncated>false</IsTruncated>
+<Part><PartNumber>1</PartNumber><ETag>"p1"</ETag><Size>5242880</Size></Part>
+<Part><PartNumber>2</PartNumber><ETag>"p2"</ETag><Size>1024</Size></Part>
+</ListPartsResult>`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case r.URL.Query().Has("versions"):
+			w.Write([]byte(versions))
+		case r.URL.Query().Has("uploads"):
+			w.Write([]byte(uploads))
+		case r.URL.Query().Get("uploadId") == "z1":
+			w.Write([]byte(parts))
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{
+			ID:         "logs",
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: "logs/"},
+			Expiration: lifecycle.Expiration{Days: 30},
+			Transition: lifecycle.Transition{Days: 7, StorageClass: "WARM"},
+		},
+		{
+			ID:                             "versions",
+			Status:                         "Enabled",
+			Expiration:                     lifecycle.Expiration{DeleteMarker: true},
+			NoncurrentVersionExpiration:    lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 10},
+			AbortIncompleteMultipartUpload: lifecycle.AbortIncompleteMultipartUpload{DaysAfterInitiation: 3},
+		},
+	}}
+
+	var (
+		got    []string
+		totals []LifecycleRuleTotal
+	)
+	for result := range client.LifecycleDryRun(t.Context(), "test-bucket", cfg, time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		if result.Totals != nil {
+			totals = result.Totals
+			continue
+		}
+		got = append(got, fmt.Sprintf("%s:%s%s %s %s %d", result.Key, result.VersionID, result.UploadID, result.RuleID, result.Action, result.Size))
+	}
+
+	expected := []string{
+		"data/x:x1 versions DeleteVersion 7",
+		"data/y:y1 versions DeleteVersion 0",
+		"logs/a.log:a1 logs Delete 100",
+		"logs/b.log:b1 logs Transition 50",
+		"data/z:z1 versions AbortMultipartUpload 5243904",
+	}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+	expectedTotals := []LifecycleRuleTotal{
+		{RuleID: "logs", Action: lifecycle.DeleteAction, Objects: 1, Bytes: 100},
+		{RuleID: "logs", Action: lifecycle.TransitionAction, StorageClass: "WARM", Objects: 1, Bytes: 50},
+		{RuleID: "versions", Action: lifecycle.DeleteVersionAction, Objects: 2, Bytes: 7},
+		{RuleID: "versions", Action: lifecycle.AbortMultipartUploadAction, Objects: 1, Bytes: 5243904},
+	}
+	if !slices.Equal(totals, expectedTotals) {
+		t.Fatalf("expected totals %+v, got %+v", expectedTotals, totals)
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	testCases := []struct {
+		prefixes []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"logs/"}, "logs/"},
+		{[]string{"logs/a", "logs/b"}, "logs/"},
+		{[]string{"logs/", "data/"}, ""},
+		// "é" and "è" share their first byte.
+		{[]string{"café/", "cafè/"}, "caf"},
+	}
+	for i, testCase := range testCases {
+		if got := commonPrefix(testCase.prefixes); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/s3utils"
@@ -517,6 +518,92 @@ func (c *Client) listObjectVersions(ctx context.Context, bucketName string, opts
 	}
 }
 
+// listObjectsAsOf - lists the objects that were live at opts.AsOf by walking
+// the version listing. For every key the newest version with a LastModified
+// not after opts.AsOf is returned, keys whose version at that instant is a
+// delete marker or which did not exist yet are skipped. Non-recursive
+// listings walk all versions below opts.Prefix once and return the common
+// prefixes of the live objects, such that prefixes with no object live at
+// opts.AsOf are left out.
+func (c *Client) listObjectsAsOf(ctx context.Context, bucketName string, opts ListObjectsOptions) iter.Seq[ObjectInfo] {
+	// Versions must be walked newest first for every key.
+	opts.WithVersions = true
+	opts.ReverseVersions = false
+	delimited := !opts.Recursive
+	opts.Recursive = true
+
+	return func(yield func(ObjectInfo) bool) {
+		var (
+			curKey     string
+			resolved   bool
+			lastPrefix string
+		)
+		for obj := range c.listObjectVersions(ctx, bucketName, opts) {
+			if obj.Err != nil {
+				yield(obj)
+				return
+			}
+			if obj.Key != curKey {
+				curKey = obj.Key
+				resolved = false
+			}
+			if resolved || obj.LastModified.After(opts.AsOf) {
+				continue
+			}
+			resolved = true
+			if obj.IsDeleteMarker {
+				continue
+			}
+			if delimited {
+				// Keys are listed in order, all live keys of a common
+				// prefix follow each other.
+				if i := strings.Index(obj.Key[len(opts.Prefix):], "/"); i >= 0 {
+					prefix := obj.Key[:len(opts.Prefix)+i+1]
+					if prefix == lastPrefix {
+						continue
+					}
+					lastPrefix = prefix
+					obj = ObjectInfo{Key: prefix}
+				}
+			}
+			if !yield(obj) {
+				return
+			}
+		}
+	}
+}
+
+// objectVersionAsOf - returns the version of objectName that was live at
+// asOf, an error response with code NoSuchKey is returned if the object did
+// not exist or was deleted at that instant.
+func (c *Client) objectVersionAsOf(ctx context.Context, bucketName, objectName string, asOf time.Time) (ObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for obj := range c.listObjectsAsOf(ctx, bucketName, ListObjectsOptions{
+		Prefix:    objectName,
+		Recursive: true,
+		AsOf:      asOf,
+	}) {
+		if obj.Err != nil {
+			return ObjectInfo{}, obj.Err
+		}
+		if obj.Key == objectName {
+			return obj, nil
+		}
+		if obj.Key > objectName {
+			break
+		}
+	}
+	return ObjectInfo{}, ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Code:       NoSuchKey,
+		Message:    s3ErrorResponseMap[NoSuchKey],
+		BucketName: bucketName,
+		Key:        objectName,
+	}
+}
+
 // listObjectVersions - (List Object Versions) - List some or all (up to 1000) of the existing objects
 // and their versions in a bucket.
 //
@@ -733,6 +820,15 @@ type ListObjectsOptions struct {
 	// Use the deprecated list objects V1 API
 	UseV1 bool
 
+	// AsOf lists the objects as they were at the given instant
+	// on a versioned bucket, the version listing is walked and
+	// for every key the latest version not newer than AsOf is
+	// returned unless it is a delete marker. Non-recursive
+	// listings walk all versions below Prefix and only return
+	// common prefixes with an object live at AsOf. WithVersions
+	// and ReverseVersions are ignored when AsOf is set.
+	AsOf time.Time
+
 	headers http.Header
 }
 
@@ -767,6 +863,8 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts ListOb
 
 		var objIter iter.Seq[ObjectInfo]
 		switch {
+		case !opts.AsOf.IsZero():
+			objIter = c.listObjectsAsOf(ctx, bucketName, opts)
 		case opts.WithVersions:
 			objIter = c.listObjectVersions(ctx, bucketName, opts)
 		case opts.UseV1:
@@ -807,6 +905,10 @@ func (c *Client) ListObjects(ctx context.Context, bucketName string, opts ListOb
 // Canceling the context the iterator will stop, if you wish to discard the yielding make sure
 // to cancel the passed context without that you might leak coroutines
 func (c *Client) ListObjectsIter(ctx context.Context, bucketName string, opts ListObjectsOptions) iter.Seq[ObjectInfo] {
+	if !opts.AsOf.IsZero() {
+		return c.listObjectsAsOf(ctx, bucketName, opts)
+	}
+
 	if opts.WithVersions {
 		return c.listObjectVersions(ctx, bucketName, opts)
 	}
@@ -1029,8 +1131,6 @@ func (c *Client) listMultipartUploadsQuery(ctx context.Context, bucketName, keyM
 }
 
 // listObjectParts list all object parts recursively.
-//
-//lint:ignore U1000 Keep this around
 func (c *Client) listObjectParts(ctx context.Context, bucketName, objectName, uploadID string) (partsInfo map[int]ObjectPart, err error) {
 	// Part number marker for the next batch of request.
 	var nextPartNumberMarker int
@@ -21,7 +21,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
@@ -62,3 +64,122 @@ func TestListObjectVersionsHonorsStartAfter(t *testing.T) {
 		t.Fatalf("expected key-marker=%q, got %q", startAfter, capturedQuery.Get("key-marker"))
 	}
 }
+
+func TestListObjectsAsOf(t *testing.T) {
+	const listing = `<ListVersionsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Name>test-bucket</Name><IsTruncated>false</IsTruncated>
+<Version><Key>a.txt</Key><VersionId>a3</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified><ETag>"a3"</ETag><Size>3</Size></Version>
+<Version><Key>a.txt</Key><VersionId>a2</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-02T00:00:00.000Z</LastModified><ETag>"a2"</ETag><Size>2</Size></Version>
+<Version><Key>a.txt</Key><VersionId>a1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"a1"</ETag><Size>1</Size></Version>
+<DeleteMarker><Key>b.txt</Key><VersionId>b2</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified></DeleteMarker>
+<Version><Key>b.txt</Key><VersionId>b1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"b1"</ETag><Size>1</Size></Version>
+<DeleteMarker><Key>c.txt</Key><VersionId>c2</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-02T00:00:00.000Z</LastModified></DeleteMarker>
+<Version><Key>c.txt</Key><VersionId>c1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"c1"</ETag><Size>1</Size></Version>
+<Version><Key>d.txt</Key><VersionId>d1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified><ETag>"d1"</ETag><Size>1</Size></Version>
+</ListVersionsResult>`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(listing))
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Secure: false,
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		asOf     time.Time
+		expected []string
+	}{
+		{time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), nil},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), []string{"a.txt:a1", "b.txt:b1", "c.txt:c1"}},
+		{time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC), []string{"a.txt:a2", "b.txt:b1"}},
+		{time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), []string{"a.txt:a3", "d.txt:d1"}},
+	}
+
+	for i, testCase := range testCases {
+		var got []string
+		for obj := range client.ListObjectsIter(t.Context(), "test-bucket", ListObjectsOptions{
+			Recursive: true,
+			AsOf:      testCase.asOf,
+		}) {
+			if obj.Err != nil {
+				t.Fatalf("Test %d: unexpected error: %v", i+1, obj.Err)
+			}
+			got = append(got, obj.Key+":"+obj.VersionID)
+		}
+		if !slices.Equal(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	objInfo, err := client.objectVersionAsOf(t.Context(), "test-bucket", "a.txt", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.VersionID != "a2" {
+		t.Errorf("expected version a2, got %s", objInfo.VersionID)
+	}
+
+	_, err = client.objectVersionAsOf(t.Context(), "test-bucket", "b.txt", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	if ToErrorResponse(err).Code != NoSuchKey {
+		t.Errorf("expected NoSuchKey, got %v", err)
+	}
+}
+
+func TestListObjectsAsOfPrefixes(t *testing.T) {
+	const listing = `<ListVersionsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>test-bucket</Name><IsTruncated>false</IsTruncated>
+<DeleteMarker><Key>deleted/x</Key><VersionId>x2</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-01T12:00:00.000Z</LastModified></DeleteMarker>
+<Version><Key>deleted/x</Key><VersionId>x1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"x1"</ETag><Size>1</Size></Version>
+<Version><Key>e.txt</Key><VersionId>e1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"e1"</ETag><Size>1</Size></Version>
+<Version><Key>new/y</Key><VersionId>y1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-05T00:00:00.000Z</LastModified><ETag>"y1"</ETag><Size>1</Size></Version>
+<Version><Key>old/a/z</Key><VersionId>z1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"z1"</ETag><Size>1</Size></Version>
+<Version><Key>old/b</Key><VersionId>b1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"b1"</ETag><Size>1</Size></Version>
+</ListVersionsResult>`
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("delimiter") != "" {
+			t.Errorf("expected a single recursive listing, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(listing))
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for obj := range client.ListObjectsIter(t.Context(), "test-bucket", ListObjectsOptions{
+		AsOf: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}) {
+		if obj.Err != nil {
+			t.Fatal(obj.Err)
+		}
+		got = append(got, obj.Key)
+	}
+	if expected := []string{"e.txt", "old/"}; !slices.Equal(got, expected) || requests != 1 {
+		t.Fatalf("expected %v with 1 request, got %v with %d requests", expected, got, requests)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// defaultPresignedPartExpiry is the default validity of presigned part URLs.
+const defaultPresignedPartExpiry = time.Hour
+
+// PresignedMultipartOptions configures NewPresignedMultipartUpload.
+type PresignedMultipartOptions struct {
+	// Expires is the validity of the part URLs, defaults to one hour.
+	Expires time.Duration
+
+	// PartSize is the size of all parts but the last, defaults to
+	// the optimal part size of the object size, see OptimalPartInfo.
+	PartSize uint64
+
+	// Checksum is the checksum algorithm of the upload, the uploader
+	// must send the checksum of every part in its x-amz-checksum-*
+	// header.
+	Checksum ChecksumType
+
+	// PartChecksums are the base64 encoded checksums of the parts, in
+	// order, computed by the uploader beforehand. They are signed so
+	// that only parts with matching data are accepted. Requires
+	// Checksum.
+	PartChecksums []string
+
+	// PutObjectOptions are the options of the object, such as its
+	// metadata, content type and storage class.
+	PutObjectOptions PutObjectOptions
+}
+
+// PresignPartOptions configures PresignUploadPart.
+type PresignPartOptions struct {
+	// Size is the exact size of the part, it is signed as the
+	// Content-Length of the upload. Any size is accepted if zero.
+	Size int64
+
+	// Checksum of the part data, it is signed so that only a part
+	// with matching data is accepted.
+	Checksum Checksum
+}
+
+// PresignedPart is a part of a multipart upload with its presigned URL.
+type PresignedPart struct {
+	PartNumber int
+	Size       int64
+
+	// URL the part is uploaded to with PUT.
+	URL *url.URL
+
+	// Header holds the signed headers the part must be uploaded
+	// with, such as Content-Length and checksums.
+	Header http.Header
+}
+
+// PresignedMultipartUpload is a multipart upload whose parts are uploaded
+// directly by a client without credentials, such as a browser.
+type PresignedMultipartUpload struct {
+	Bucket   string
+	Object   string
+	UploadID string
+	Parts    []PresignedPart
+}
+
+// NewPresignedMultipartUpload initiates a multipart upload of an object of
+// size bytes and returns presigned URLs for all of its parts. The parts
+// are uploaded with PUT on their URL, the ETag response headers are then
+// reported to CompletePresignedMultipartUpload. Uploads which are not
+// completed should be removed with AbortMultipartUpload.
+//
+// The size of every part is signed, uploads of parts with a different size
+// are rejected.
+func (c Core) NewPresignedMultipartUpload(ctx context.Context, bucket, object string, size int64, opts PresignedMultipartOptions) (*PresignedMultipartUpload, error) {
+	if size <= 0 {
+		return nil, errInvalidArgument("Object size must be known and greater than zero.")
+	}
+	expires := opts.Expires
+	if expires == 0 {
+		expires = defaultPresignedPartExpiry
+	}
+	if err := isValidExpiry(expires); err != nil {
+		return nil, err
+	}
+	totalPartsCount, partSize, lastPartSize, err := OptimalPartInfo(size, opts.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.PartChecksums) > 0 {
+		if !opts.Checksum.IsSet() {
+			return nil, errInvalidArgument("Part checksums require a checksum type.")
+		}
+		if len(opts.PartChecksums) != totalPartsCount {
+			return nil, errInvalidArgument(fmt.Sprintf("Expected %d part checksums, got %d.", totalPartsCount, len(opts.PartChecksums)))
+		}
+	}
+
+	putOpts := opts.PutObjectOptions
+	if opts.Checksum.IsSet() {
+		putOpts.UserMetadata = maps.Clone(putOpts.UserMetadata)
+		putOpts.AutoChecksum = opts.Checksum
+		addAutoChecksumHeaders(&putOpts)
+	}
+	uploadID, err := c.NewMultipartUpload(ctx, bucket, object, putOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &PresignedMultipartUpload{
+		Bucket:   bucket,
+		Object:   object,
+		UploadID: uploadID,
+		Parts:    make([]PresignedPart, 0, totalPartsCount),
+	}
+	for partNumber := 1; partNumber <= totalPartsCount; partNumber++ {
+		partOpts := PresignPartOptions{Size: partSize}
+		if partNumber == totalPartsCount {
+			partOpts.Size = lastPartSize
+		}
+		if len(opts.PartChecksums) > 0 {
+			partOpts.Checksum = NewChecksumString(opts.Checksum, opts.PartChecksums[partNumber-1])
+		}
+		part, err := c.PresignUploadPart(ctx, bucket, object, uploadID, partNumber, expires, partOpts)
+		if err != nil {
+			// Nobody can upload to the upload without its part URLs.
+			c.AbortMultipartUpload(ctx, bucket, object, uploadID)
+			return nil, err
+		}
+		upload.Parts = append(upload.Parts, part)
+	}
+	return upload, nil
+}
+
+// PresignUploadPart returns a presigned URL uploading a part of a multipart
+// upload, such as to renew an expired part URL of a presigned multipart
+// upload.
+func (c Core) PresignUploadPart(ctx context.Context, bucket, object, uploadID string, partNumber int, expires time.Duration, opts PresignPartOptions) (PresignedPart, error) {
+	if partNumber < 1 || partNumber > maxPartsCount {
+		return PresignedPart{}, errInvalidArgument(fmt.Sprintf("Part number must be between 1 and %d.", maxPartsCount))
+	}
+	if uploadID == "" {
+		return PresignedPart{}, errInvalidArgument("Upload ID cannot be empty.")
+	}
+	if opts.Size < 0 || opts.Size > maxPartSize {
+		return PresignedPart{}, errInvalidArgument("Part size must be between 0 and 5GiB.")
+	}
+
+	header := make(http.Header)
+	if opts.Size > 0 {
+		header.Set("Content-Length", strconv.FormatInt(opts.Size, 10))
+	}
+	if opts.Checksum.IsSet() {
+		header.Set(opts.Checksum.Type.Key(), opts.Checksum.Encoded())
+	}
+
+	query := make(url.Values)
+	query.Set("partNumber", strconv.Itoa(partNumber))
+	query.Set("uploadId", uploadID)
+	u, err := c.PresignHeader(ctx, http.MethodPut, bucket, object, expires, query, header)
+	if err != nil {
+		return PresignedPart{}, err
+	}
+	return PresignedPart{
+		PartNumber: partNumber,
+		Size:       opts.Size,
+		URL:        u,
+		Header:     header,
+	}, nil
+}
+
+// CompletePresignedMultipartUpload completes a multipart upload with the
+// parts reported by the uploader. As the uploader is not trusted the parts
+// are checked against the uploaded parts, every reported part must have
+// been uploaded with the reported ETag. Checksums of the parts are taken
+// from the uploaded parts.
+func (c Core) CompletePresignedMultipartUpload(ctx context.Context, bucket, object, uploadID string, parts []CompletePart, opts PutObjectOptions) (UploadInfo, error) {
+	if len(parts) == 0 {
+		return UploadInfo{}, errInvalidArgument("At least one part is required.")
+	}
+	uploaded, err := c.listObjectParts(ctx, bucket, object, uploadID)
+	if err != nil {
+		return UploadInfo{}, err
+	}
+
+	complete := make([]CompletePart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return UploadInfo{}, errInvalidArgument("Parts must be in ascending order of part numbers.")
+		}
+		p, ok := uploaded[part.PartNumber]
+		if !ok || trimEtag(part.ETag) != p.ETag {
+			return UploadInfo{}, errInvalidArgument(fmt.Sprintf("Part %d was not uploaded with ETag %s.", part.PartNumber, part.ETag))
+		}
+		complete = append(complete, CompletePart{
+			PartNumber:        p.PartNumber,
+			ETag:              p.ETag,
+			ChecksumCRC32:     p.ChecksumCRC32,
+			ChecksumCRC32C:    p.ChecksumCRC32C,
+			ChecksumSHA1:      p.ChecksumSHA1,
+			ChecksumSHA256:    p.ChecksumSHA256,
+			ChecksumCRC64NVME: p.ChecksumCRC64NVME,
+		})
+	}
+	return c.CompleteMultipartUpload(ctx, bucket, object, uploadID, complete, opts)
+}
@@ -0,0 +1,139 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// uploadPresignedPart uploads data like a browser would and returns the
+// response.
+func uploadPresignedPart(t *testing.T, part PresignedPart, data []byte) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, part.URL.String(), bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range part.Header {
+		if k != "Content-Length" {
+			req.Header[k] = v
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp
+}
+
+func TestPresignedMultipartUpload(t *testing.T) {
+	clnt := newVerifyingServer(t, "us-east-1")
+	clnt.credsProvider = credentials.NewStaticV4("accesskey", "secretkey", "")
+	core := Core{clnt}
+	ctx := context.Background()
+	if err := clnt.MakeBucket(ctx, "bucket", MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), absMinPartSize+1024)
+	crc := ChecksumCRC32C.ChecksumBytes(data[absMinPartSize:])
+	upload, err := core.NewPresignedMultipartUpload(ctx, "bucket", "object", int64(len(data)), PresignedMultipartOptions{
+		Expires:       time.Minute,
+		PartSize:      absMinPartSize,
+		Checksum:      ChecksumCRC32C,
+		PartChecksums: []string{ChecksumCRC32C.ChecksumBytes(data[:absMinPartSize]).Encoded(), crc.Encoded()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upload.Parts) != 2 || upload.Parts[0].Size != absMinPartSize || upload.Parts[1].Size != 1024 {
+		t.Fatalf("unexpected parts %+v", upload.Parts)
+	}
+
+	// Parts with a different size or checksum are rejected.
+	if resp := uploadPresignedPart(t, upload.Parts[1], data[:1023]); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected size mismatch to be rejected, got %s", resp.Status)
+	}
+	tampered := upload.Parts[1]
+	tampered.Header = http.Header{ChecksumCRC32C.Key(): {ChecksumCRC32C.ChecksumBytes(nil).Encoded()}}
+	if resp := uploadPresignedPart(t, tampered, data[absMinPartSize:]); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected checksum mismatch to be rejected, got %s", resp.Status)
+	}
+
+	var parts []CompletePart
+	for i, part := range upload.Parts {
+		end := min(int64(len(data)), int64(i+1)*absMinPartSize)
+		resp := uploadPresignedPart(t, part, data[int64(i)*absMinPartSize:end])
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected response %s", resp.Status)
+		}
+		parts = append(parts, CompletePart{PartNumber: part.PartNumber, ETag: resp.Header.Get("ETag")})
+	}
+
+	forged := []CompletePart{parts[0], {PartNumber: 2, ETag: "forged"}}
+	if _, err = core.CompletePresignedMultipartUpload(ctx, "bucket", "object", upload.UploadID, forged, PutObjectOptions{}); err == nil {
+		t.Fatal("expected forged ETag to be rejected")
+	}
+	if _, err = core.CompletePresignedMultipartUpload(ctx, "bucket", "object", upload.UploadID, parts, PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := clnt.StatObject(ctx, "bucket", "object", StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != int64(len(data)) {
+		t.Fatalf("expected size %d, got %d", len(data), info.Size)
+	}
+}
+
+func TestPresignedMultipartUploadAbort(t *testing.T) {
+	clnt := newVerifyingServer(t, "us-east-1")
+	clnt.credsProvider = credentials.NewStaticV4("accesskey", "secretkey", "")
+	core := Core{clnt}
+	ctx := context.Background()
+	if err := clnt.MakeBucket(ctx, "bucket", MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Part URLs cannot be presigned with anonymous credentials.
+	clnt.bucketCreds = func(_, method string) *credentials.Credentials {
+		if method == http.MethodPut {
+			return credentials.NewStatic("", "", "", credentials.SignatureAnonymous)
+		}
+		return nil
+	}
+
+	if _, err := core.NewPresignedMultipartUpload(ctx, "bucket", "object", absMinPartSize+1024, PresignedMultipartOptions{PartSize: absMinPartSize}); err == nil {
+		t.Fatal("expected presigning with anonymous credentials to fail")
+	}
+	for upload := range clnt.ListIncompleteUploads(ctx, "bucket", "", true) {
+		if upload.Err != nil {
+			t.Fatal(upload.Err)
+		}
+		t.Fatalf("expected the upload to be aborted, got %s", upload.UploadID)
+	}
+}
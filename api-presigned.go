@@ -140,7 +140,7 @@ func (c *Client) PresignedPostPolicy(ctx context.Context, p *PostPolicy) (u *url
 	}
 
 	// Get credentials from the configured credentials provider.
-	credValues, err := c.credsProvider.GetWithContext(c.CredContext())
+	credValues, err := c.credsFor(bucketName, http.MethodPost).GetWithContext(c.CredContext())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +160,9 @@ func (c *Client) PresignedPostPolicy(ctx context.Context, p *PostPolicy) (u *url
 	t := time.Now().UTC()
 	// For signature version '2' handle here.
 	if signerType.IsV2() {
+		if c.externalSigner != nil {
+			return nil, nil, errInvalidArgument("Only signature v4 is supported with an external signer.")
+		}
 		policyBase64 := p.base64()
 		p.formData["policy"] = policyBase64
 		// For Google endpoint set this value to be 'GoogleAccessId'.
@@ -223,6 +226,14 @@ func (c *Client) PresignedPostPolicy(ctx context.Context, p *PostPolicy) (u *url
 	if sessionToken != "" {
 		p.formData["x-amz-security-token"] = sessionToken
 	}
+	if c.externalSigner != nil {
+		signature, err := signer.PostPresignSignatureV4WithSigner(ctx, policyBase64, t, c.externalSigner, location)
+		if err != nil {
+			return nil, nil, err
+		}
+		p.formData["x-amz-signature"] = signature
+		return u, p.formData, nil
+	}
 	p.formData["x-amz-signature"] = signer.PostPresignSignatureV4(policyBase64, t, secretAccessKey, location)
 	return u, p.formData, nil
 }
@@ -0,0 +1,209 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// RestoreNewerObjects decides what RestorePrefixToTime does with objects
+// that did not exist at the restore point.
+type RestoreNewerObjects int
+
+const (
+	// RestoreNewerObjectsSkip leaves objects created after the restore
+	// point untouched.
+	RestoreNewerObjectsSkip RestoreNewerObjects = iota
+	// RestoreNewerObjectsDelete places a delete marker on objects created
+	// after the restore point.
+	RestoreNewerObjectsDelete
+)
+
+// RestorePrefixAction is the action taken on a single object by
+// RestorePrefixToTime.
+type RestorePrefixAction string
+
+const (
+	// RestorePrefixActionNone - latest version already matches the restore point.
+	RestorePrefixActionNone RestorePrefixAction = "none"
+	// RestorePrefixActionCopy - version at the restore point copied back as latest.
+	RestorePrefixActionCopy RestorePrefixAction = "copy"
+	// RestorePrefixActionDelete - delete marker placed on an object newer than the restore point.
+	RestorePrefixActionDelete RestorePrefixAction = "delete"
+	// RestorePrefixActionSkip - object newer than the restore point left as is.
+	RestorePrefixActionSkip RestorePrefixAction = "skip"
+)
+
+// RestorePrefixOptions represents options for RestorePrefixToTime call
+type RestorePrefixOptions struct {
+	// NewerObjects decides what happens to objects that
+	// did not exist at the restore point, defaults to
+	// skipping them.
+	NewerObjects RestoreNewerObjects
+
+	// DryRun only reports the actions that would be taken
+	// without modifying the bucket.
+	DryRun bool
+
+	// ServerSideEncryption is used to read the restored
+	// versions and to write the new latest versions, needed
+	// for objects encrypted with SSE-C.
+	ServerSideEncryption encrypt.ServerSide
+}
+
+// RestorePrefixResult is the per object report of RestorePrefixToTime.
+type RestorePrefixResult struct {
+	Key    string
+	Action RestorePrefixAction
+
+	// RestoredVersionID is the version that was live at the restore
+	// point, empty if the object did not exist at that time.
+	RestoredVersionID string
+
+	// LatestVersionID is the latest version before the restore.
+	LatestVersionID string
+
+	// VersionID is the version created by the restore, either the
+	// copied version or the delete marker. Empty on dry runs.
+	VersionID string
+
+	// DryRun is set if no change was made to the bucket.
+	DryRun bool
+
+	Err error
+}
+
+// RestorePrefixToTime makes the state of all objects under prefix as of
+// the restore point t current again on a versioned bucket. Objects whose
+// version at t differs from the latest version get that version copied
+// back as the new latest version, objects that did not exist at t are
+// skipped or deleted depending on opts.NewerObjects. A result is yielded
+// for every object key visited, listing errors end the iteration.
+//
+//	api := client.New(....)
+//	for res := range api.RestorePrefixToTime(ctx, "mybucket", "data/", t, minio.RestorePrefixOptions{DryRun: true}) {
+//	    if res.Err != nil {
+//	        // handle the errors.
+//	    }
+//	    fmt.Println(res.Key, res.Action)
+//	}
+func (c *Client) RestorePrefixToTime(ctx context.Context, bucketName, prefix string, t time.Time, opts RestorePrefixOptions) iter.Seq[RestorePrefixResult] {
+	return func(yield func(RestorePrefixResult) bool) {
+		if err := s3utils.CheckValidBucketName(bucketName); err != nil {
+			yield(RestorePrefixResult{Err: err})
+			return
+		}
+		if t.IsZero() {
+			yield(RestorePrefixResult{Err: errInvalidArgument("Restore point cannot be empty.")})
+			return
+		}
+
+		var (
+			latest, target ObjectInfo
+			found          bool
+		)
+		for obj := range c.listObjectVersions(ctx, bucketName, ListObjectsOptions{
+			Prefix:       prefix,
+			Recursive:    true,
+			WithVersions: true,
+		}) {
+			if obj.Err != nil {
+				yield(RestorePrefixResult{Err: obj.Err})
+				return
+			}
+			if obj.Key != latest.Key {
+				if latest.Key != "" {
+					if !yield(c.restoreObjectToVersion(ctx, bucketName, latest, target, found, opts)) {
+						return
+					}
+				}
+				latest, target, found = obj, ObjectInfo{}, false
+			}
+			if !found && !obj.LastModified.After(t) {
+				target, found = obj, true
+			}
+		}
+		if latest.Key != "" {
+			yield(c.restoreObjectToVersion(ctx, bucketName, latest, target, found, opts))
+		}
+	}
+}
+
+// restoreObjectToVersion - makes target the latest version of the object,
+// found is false when no version existed at the restore point.
+func (c *Client) restoreObjectToVersion(ctx context.Context, bucketName string, latest, target ObjectInfo, found bool, opts RestorePrefixOptions) RestorePrefixResult {
+	res := RestorePrefixResult{
+		Key:             latest.Key,
+		LatestVersionID: latest.VersionID,
+		DryRun:          opts.DryRun,
+	}
+	if found && !target.IsDeleteMarker {
+		res.RestoredVersionID = target.VersionID
+	}
+
+	switch {
+	case res.RestoredVersionID == "" && latest.IsDeleteMarker:
+		res.Action = RestorePrefixActionNone
+	case res.RestoredVersionID == "" && opts.NewerObjects == RestoreNewerObjectsSkip:
+		res.Action = RestorePrefixActionSkip
+	case res.RestoredVersionID == "":
+		res.Action = RestorePrefixActionDelete
+	case res.RestoredVersionID == latest.VersionID:
+		res.Action = RestorePrefixActionNone
+	default:
+		res.Action = RestorePrefixActionCopy
+	}
+
+	if opts.DryRun {
+		return res
+	}
+
+	switch res.Action {
+	case RestorePrefixActionDelete:
+		rmRes := c.removeObject(ctx, bucketName, latest.Key, RemoveObjectOptions{})
+		res.VersionID, res.Err = rmRes.DeleteMarkerVersionID, rmRes.Err
+	case RestorePrefixActionCopy:
+		dst := CopyDestOptions{
+			Bucket:     bucketName,
+			Object:     target.Key,
+			Encryption: opts.ServerSideEncryption,
+		}
+		src := CopySrcOptions{
+			Bucket:    bucketName,
+			Object:    target.Key,
+			VersionID: target.VersionID,
+		}
+		// Only SSE-C keys are needed to read the source version.
+		if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Type() == encrypt.SSEC {
+			src.Encryption = opts.ServerSideEncryption
+		}
+		var info UploadInfo
+		if target.Size > maxSinglePutObjectSize {
+			info, res.Err = c.ComposeObject(ctx, dst, src)
+		} else {
+			info, res.Err = c.CopyObject(ctx, dst, src)
+		}
+		res.VersionID = info.VersionID
+	}
+	return res
+}
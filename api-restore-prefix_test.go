@@ -0,0 +1,139 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestRestorePrefixToTime(t *testing.T) {
+	const listing = `<ListVersionsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Name>test-bucket</Name><IsTruncated>false</IsTruncated>
+<Version><Key>data/a</Key><VersionId>a2</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified><ETag>"a2"</ETag><Size>2</Size></Version>
+<Version><Key>data/a</Key><VersionId>a1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"a1"</ETag><Size>1</Size></Version>
+<DeleteMarker><Key>data/b</Key><VersionId>b2</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified></DeleteMarker>
+<Version><Key>data/b</Key><VersionId>b1</VersionId><IsLatest>false</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"b1"</ETag><Size>1</Size></Version>
+<Version><Key>data/c</Key><VersionId>c1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-01T00:00:00.000Z</LastModified><ETag>"c1"</ETag><Size>1</Size></Version>
+<Version><Key>data/d</Key><VersionId>d1</VersionId><IsLatest>true</IsLatest><LastModified>2024-01-03T00:00:00.000Z</LastModified><ETag>"d1"</ETag><Size>1</Size></Version>
+</ListVersionsResult>`
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(listing))
+		case http.MethodPut:
+			requests = append(requests, "copy "+r.Header.Get("x-amz-copy-source"))
+			w.Header().Set("x-amz-version-id", "new")
+			w.Write([]byte(`<CopyObjectResult><ETag>"new"</ETag><LastModified>2024-01-04T00:00:00.000Z</LastModified></CopyObjectResult>`))
+		case http.MethodDelete:
+			requests = append(requests, "delete "+r.URL.Path)
+			w.Header().Set("x-amz-delete-marker", "true")
+			w.Header().Set("x-amz-version-id", "marker")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer ts.Close()
+
+	srv, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := New(srv.Host, &Options{
+		Creds:  credentials.NewStaticV4("accesskey", "secretkey", ""),
+		Secure: false,
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restorePoint := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	expected := map[string]RestorePrefixAction{
+		"data/a": RestorePrefixActionCopy,
+		"data/b": RestorePrefixActionCopy,
+		"data/c": RestorePrefixActionNone,
+		"data/d": RestorePrefixActionDelete,
+	}
+
+	// Dry run must not modify anything.
+	for res := range client.RestorePrefixToTime(t.Context(), "test-bucket", "data/", restorePoint, RestorePrefixOptions{
+		DryRun:       true,
+		NewerObjects: RestoreNewerObjectsDelete,
+	}) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		if res.Action != expected[res.Key] {
+			t.Errorf("%s: expected %s, got %s", res.Key, expected[res.Key], res.Action)
+		}
+		if !res.DryRun || res.VersionID != "" {
+			t.Errorf("%s: expected dry run result, got %+v", res.Key, res)
+		}
+	}
+	if len(requests) != 0 {
+		t.Fatalf("dry run modified the bucket: %v", requests)
+	}
+
+	var seen int
+	for res := range client.RestorePrefixToTime(t.Context(), "test-bucket", "data/", restorePoint, RestorePrefixOptions{}) {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		seen++
+		want := expected[res.Key]
+		if res.Key == "data/d" {
+			want = RestorePrefixActionSkip
+		}
+		if res.Action != want {
+			t.Errorf("%s: expected %s, got %s", res.Key, want, res.Action)
+		}
+		if res.Action == RestorePrefixActionCopy && res.VersionID != "new" {
+			t.Errorf("%s: expected new version, got %q", res.Key, res.VersionID)
+		}
+	}
+	if seen != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), seen)
+	}
+	want := []string{
+		"copy test-bucket/data/a?versionId=a1",
+		"copy test-bucket/data/b?versionId=b1",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("expected requests %v, got %v", want, requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("expected request %q, got %q", want[i], requests[i])
+		}
+	}
+}
@@ -74,6 +74,13 @@ func (c *Client) StatObject(ctx context.Context, bucketName, objectName string,
 			Message:    err.Error(),
 		}
 	}
+	if opts.VersionID == "" && !opts.AsOf.IsZero() {
+		objInfo, err := c.objectVersionAsOf(ctx, bucketName, objectName, opts.AsOf)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		opts.VersionID = objInfo.VersionID
+	}
 	headers := opts.Header()
 	if opts.Internal.ReplicationDeleteMarker {
 		headers.Set(minIOBucketReplicationDeleteMarker, "true")
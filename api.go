@@ -59,6 +59,9 @@ type Client struct {
 	// Holds various credential providers.
 	credsProvider *credentials.Credentials
 
+	// Optional per bucket credentials, see Options.BucketCreds.
+	bucketCreds func(bucketName, method string) *credentials.Credentials
+
 	// Custom signerType value overrides all credentials.
 	overrideSignerType credentials.SignatureType
 
@@ -109,6 +112,12 @@ type Client struct {
 
 	trailingHeaderSupport bool
 	maxRetries            int
+
+	// Regions of signature v4a requests.
+	regionSet []string
+
+	// Signs requests instead of the secret key when set.
+	externalSigner signer.Signer
 }
 
 // Options for New method
@@ -156,6 +165,25 @@ type Options struct {
 	// Number of times a request is retried. Defaults to 10 retries if this option is not configured.
 	// Set to 1 to disable retries.
 	MaxRetries int
+
+	// RegionSet is the set of regions requests signed with signature
+	// v4a, see credentials.SignatureV4A, are valid for. "*" allows all
+	// regions, as required by multi-region access points. Defaults to
+	// the bucket location.
+	RegionSet []string
+
+	// Signer computes the signature v4 signatures of requests instead
+	// of the secret key of Creds, which only needs to provide the access
+	// key and session token, see credentials.NewStaticAccessKey. This
+	// allows keeping the secret key in an HSM or a signing sidecar.
+	Signer signer.Signer
+
+	// BucketCreds, if set, returns the credentials of requests with the
+	// HTTP method to a bucket, such that a single client and its
+	// connection pool serve buckets owned by different tenants. Creds
+	// are used for requests without a bucket, such as ListBuckets, and
+	// when it returns nil.
+	BucketCreds func(bucketName, method string) *credentials.Credentials
 }
 
 // Global constants.
@@ -255,6 +283,7 @@ func privateNew(endpoint string, opts *Options) (*Client, error) {
 
 	// Save the credentials.
 	clnt.credsProvider = opts.Creds
+	clnt.bucketCreds = opts.BucketCreds
 
 	// Remember whether we are using https or not
 	clnt.secure = opts.Secure
@@ -311,6 +340,8 @@ func privateNew(endpoint string, opts *Options) (*Client, error) {
 	}
 
 	clnt.trailingHeaderSupport = opts.TrailingHeaders && clnt.overrideSignerType.IsV4()
+	clnt.regionSet = opts.RegionSet
+	clnt.externalSigner = opts.Signer
 
 	// Sets bucket lookup style, whether server accepts DNS or Path lookup. Default is Auto - determined
 	// by the SDK. When Auto is specified, DNS lookup is used for Amazon/Google cloud endpoints and Path for all other endpoints.
@@ -818,6 +849,17 @@ func (c *Client) executeMethod(ctx context.Context, method string, metadata requ
 	return res, err
 }
 
+// signerServiceType returns the signature v4 service type of the endpoint.
+func (c *Client) signerServiceType() string {
+	switch {
+	case s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL):
+		return signer.ServiceTypeS3Express
+	case s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL):
+		return signer.ServiceTypeS3Outposts
+	}
+	return signer.ServiceTypeS3
+}
+
 // newRequest - instantiate a new HTTP request for a given method.
 func (c *Client) newRequest(ctx context.Context, method string, metadata requestMetadata) (req *http.Request, err error) {
 	// If no method is supplied default to 'POST'.
@@ -858,12 +900,18 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 
 	// make sure to de-dup calls to credential services, this reduces
 	// the overall load to the endpoint generating credential service.
-	value, err, _ := c.credsGroup.Do(metadata.bucketName, func() (credentials.Value, error) {
-		if s3utils.IsS3ExpressBucket(metadata.bucketName) && s3utils.IsAmazonEndpoint(*c.endpointURL) {
+	groupKey := metadata.bucketName
+	if c.bucketCreds != nil {
+		// Per bucket credentials may differ by method.
+		groupKey += "/" + method
+	}
+	s3ExpressSession := s3utils.IsS3ExpressBucket(metadata.bucketName) && s3utils.IsAmazonEndpoint(*c.endpointURL)
+	value, err, _ := c.credsGroup.Do(groupKey, func() (credentials.Value, error) {
+		if s3ExpressSession {
 			return c.CreateSession(ctx, metadata.bucketName, SessionReadWrite)
 		}
 		// Get credentials from the configured credentials provider.
-		return c.credsProvider.GetWithContext(c.CredContext())
+		return c.credsFor(metadata.bucketName, method).GetWithContext(c.CredContext())
 	})
 	if err != nil {
 		return nil, err
@@ -886,8 +934,9 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		req.Header.Set("x-amz-s3session-token", sessionToken)
 	}
 
-	// Custom signer set then override the behavior.
-	if c.overrideSignerType != credentials.SignatureDefault {
+	// Custom signer set then override the behavior, signature v4a
+	// is kept as it is accepted wherever v4 is.
+	if c.overrideSignerType != credentials.SignatureDefault && !signerType.IsV4A() {
 		signerType = c.overrideSignerType
 	}
 
@@ -897,6 +946,22 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		signerType = credentials.SignatureAnonymous
 	}
 
+	regionSet := c.regionSet
+	if len(regionSet) == 0 {
+		regionSet = []string{location}
+	}
+
+	// The secret key of S3 Express sessions is only known to the client,
+	// the external signer only signs the CreateSession request.
+	externalSigner := c.externalSigner
+	if s3ExpressSession {
+		externalSigner = nil
+	}
+
+	if externalSigner != nil && (signerType.IsV2() || signerType.IsV4A()) {
+		return nil, errInvalidArgument("Only signature v4 is supported with an external signer.")
+	}
+
 	// Generate presign url if needed, return right here.
 	if metadata.expires != 0 && metadata.presignURL {
 		if signerType.IsAnonymous() {
@@ -913,6 +978,16 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		if signerType.IsV2() {
 			// Presign URL with signature v2.
 			req = signer.PreSignV2(*req, accessKeyID, secretAccessKey, metadata.expires, isVirtualHost)
+		} else if externalSigner != nil {
+			// Presign URL with the external signer.
+			serviceType := signer.ServiceTypeS3
+			if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
+				serviceType = signer.ServiceTypeS3Outposts
+			}
+			return signer.PreSignV4WithSigner(ctx, *req, externalSigner, accessKeyID, sessionToken, location, serviceType, metadata.expires)
+		} else if signerType.IsV4A() {
+			// Presign URL with signature v4a.
+			return signer.PreSignV4A(*req, accessKeyID, secretAccessKey, sessionToken, regionSet, metadata.expires)
 		} else if signerType.IsV4() {
 			// Presign URL with signature v4.
 			if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
@@ -975,7 +1050,19 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		// Streaming signature is used by default for a PUT object request.
 		// Additionally, we also look if the initialized client is secure,
 		// if yes then we don't need to perform streaming signature.
-		if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
+		if externalSigner != nil {
+			req, err = signer.StreamingSignV4WithSigner(ctx, req, externalSigner, accessKeyID,
+				sessionToken, location, c.signerServiceType(), metadata.contentLength, time.Now().UTC(), c.sha256Hasher())
+			if err != nil {
+				return nil, err
+			}
+		} else if signerType.IsV4A() {
+			req, err = signer.StreamingSignV4A(req, accessKeyID,
+				secretAccessKey, sessionToken, regionSet, metadata.contentLength, time.Now().UTC(), c.sha256Hasher())
+			if err != nil {
+				return nil, err
+			}
+		} else if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
 			req = signer.StreamingSignV4Express(req, accessKeyID,
 				secretAccessKey, sessionToken, location, metadata.contentLength, time.Now().UTC(), c.sha256Hasher())
 		} else if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
@@ -999,7 +1086,19 @@ func (c *Client) newRequest(ctx context.Context, method string, metadata request
 		}
 		req.Header.Set("X-Amz-Content-Sha256", shaHeader)
 
-		if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
+		if externalSigner != nil {
+			// Add signature version '4' authorization header computed by the external signer.
+			req, err = signer.SignV4WithSigner(ctx, *req, externalSigner, accessKeyID, sessionToken, location, c.signerServiceType(), metadata.trailer)
+			if err != nil {
+				return nil, err
+			}
+		} else if signerType.IsV4A() {
+			// Add signature version '4a' authorization header.
+			req, err = signer.SignV4ATrailer(*req, accessKeyID, secretAccessKey, sessionToken, regionSet, metadata.trailer)
+			if err != nil {
+				return nil, err
+			}
+		} else if s3utils.IsAmazonExpressRegionalEndpoint(*c.endpointURL) {
 			req = signer.SignV4TrailerExpress(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.trailer)
 		} else if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
 			req = signer.SignV4TrailerOutposts(*req, accessKeyID, secretAccessKey, sessionToken, location, metadata.trailer)
@@ -1149,3 +1248,14 @@ func (c *Client) GetCreds() (credentials.Value, error) {
 	}
 	return c.credsProvider.GetWithContext(c.CredContext())
 }
+
+// credsFor returns the credentials of requests with method to bucketName,
+// see Options.BucketCreds.
+func (c *Client) credsFor(bucketName, method string) *credentials.Credentials {
+	if c.bucketCreds != nil && bucketName != "" {
+		if creds := c.bucketCreds(bucketName, method); creds != nil {
+			return creds
+		}
+	}
+	return c.credsProvider
+}
@@ -18,11 +18,23 @@
 package minio
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio-go/v7/pkg/s3test"
+	"github.com/minio/minio-go/v7/pkg/signer"
 )
 
 // Tests valid hosts for location.
@@ -92,6 +104,218 @@ func TestSignatureType(t *testing.T) {
 	}
 }
 
+// newVerifyingServer returns an s3test server verifying the signatures of
+// requests signed for region with the secret key "secretkey".
+func newVerifyingServer(t *testing.T, region string) *Client {
+	t.Helper()
+	srv := s3test.NewServer()
+	t.Cleanup(srv.Close)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookup := func(string) (string, error) { return "secretkey", nil }
+		opts := signer.VerifyOptions{Region: region}
+		var err error
+		if r.URL.Query().Has("X-Amz-Signature") {
+			_, err = signer.VerifyPresignedV4(r, lookup, opts)
+		} else {
+			_, err = signer.VerifyV4(r, lookup, opts)
+		}
+		if err != nil {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code><Message>"+err.Error()+"</Message></Error>")
+			return
+		}
+		// The body is decoded by VerifyV4.
+		r.Header.Del("X-Amz-Content-Sha256")
+		srv.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt, err := New(u.Host, &Options{Region: region})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clnt
+}
+
+// testSignedRequests runs signed and presigned requests against clnt.
+func testSignedRequests(t *testing.T, clnt *Client) {
+	t.Helper()
+	ctx := context.Background()
+	if err := clnt.MakeBucket(ctx, "bucket", MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 100*1024)
+	if _, err := clnt.PutObject(ctx, "bucket", "object", bytes.NewReader(data), int64(len(data)), PutObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	presignedURL, err := clnt.PresignedGetObject(ctx, "bucket", "object", time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(presignedURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK || !bytes.Equal(got, data) {
+		t.Fatalf("unexpected response %s: %v", resp.Status, err)
+	}
+}
+
+// Tests requests signed with signature v4a.
+func TestSignatureV4A(t *testing.T) {
+	clnt := newVerifyingServer(t, "eu-west-1")
+	clnt.credsProvider = credentials.NewStaticV4A("accesskey", "secretkey", "")
+	testSignedRequests(t, clnt)
+
+	presignedURL, err := clnt.PresignedGetObject(context.Background(), "bucket", "object", time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(presignedURL.RawQuery, "X-Amz-Region-Set=eu-west-1") {
+		t.Fatalf("unexpected presigned URL %s", presignedURL)
+	}
+}
+
+// externalSigner signs with the secret key "secretkey".
+type externalSigner struct{}
+
+func (externalSigner) Sign(_ context.Context, scope signer.Scope, stringToSign string) (string, error) {
+	mac := hmac.New(sha256.New, signer.DeriveSigningKey("secretkey", scope))
+	mac.Write([]byte(stringToSign))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Tests requests signed with an external signer.
+func TestExternalSigner(t *testing.T) {
+	clnt := newVerifyingServer(t, "us-east-1")
+	clnt.credsProvider = credentials.NewStaticAccessKey("accesskey", "")
+	clnt.externalSigner = externalSigner{}
+	testSignedRequests(t, clnt)
+
+	clnt.credsProvider = credentials.NewStaticV2("accesskey", "secretkey", "")
+	if _, err := clnt.PresignedGetObject(context.Background(), "bucket", "object", time.Minute, nil); err == nil {
+		t.Fatal("expected signature v2 to be rejected with an external signer")
+	}
+}
+
+// countingSigner counts the signatures it computes.
+type countingSigner struct {
+	externalSigner
+	calls int
+}
+
+func (s *countingSigner) Sign(ctx context.Context, scope signer.Scope, stringToSign string) (string, error) {
+	s.calls++
+	return s.externalSigner.Sign(ctx, scope, stringToSign)
+}
+
+// Tests requests to S3 Express buckets are signed with the session secret
+// rather than the external signer, which only signs CreateSession.
+func TestExternalSignerS3Express(t *testing.T) {
+	const bucketName = "bucket--use1-az4--x-s3"
+
+	sign := &countingSigner{}
+	clnt, err := New("s3.us-east-1.amazonaws.com", &Options{
+		Creds:  credentials.NewStaticAccessKey("accesskey", ""),
+		Region: "us-east-1",
+		Secure: true,
+		Signer: sign,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = clnt.createSessionRequest(context.Background(), bucketName, SessionReadWrite); err != nil {
+		t.Fatal(err)
+	}
+	if sign.calls != 1 {
+		t.Fatalf("expected CreateSession to be signed by the external signer, got %d signatures", sign.calls)
+	}
+
+	clnt.bucketSessionCache.Set(bucketName, credentials.Value{
+		AccessKeyID:     "sessionkey",
+		SecretAccessKey: "sessionsecret",
+		SessionToken:    "sessiontoken",
+		Expiration:      time.Now().Add(time.Hour),
+	})
+	req, err := clnt.newRequest(context.Background(), http.MethodGet, requestMetadata{
+		bucketName:       bucketName,
+		objectName:       "object",
+		bucketLocation:   "us-east-1",
+		contentSHA256Hex: emptySHA256Hex,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sign.calls != 1 {
+		t.Fatalf("expected the session request not to be signed by the external signer, got %d signatures", sign.calls)
+	}
+	if auth := req.Header.Get("Authorization"); !strings.Contains(auth, "Credential=sessionkey/") {
+		t.Fatalf("expected the request to be signed with the session credentials, got %q", auth)
+	}
+	if req.Header.Get("x-amz-s3session-token") != "sessiontoken" {
+		t.Fatalf("expected the session token, got %q", req.Header.Get("x-amz-s3session-token"))
+	}
+}
+
+func TestBucketCreds(t *testing.T) {
+	keys := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		_, credential, _ := strings.Cut(auth, "Credential=")
+		accessKey, _, _ := strings.Cut(credential, "/")
+		keys <- r.Method + " " + r.URL.Path + " " + accessKey
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := credentials.NewStaticV4("tenant", "secretkey", "")
+	readOnly := credentials.NewStaticV4("readonly", "secretkey", "")
+	clnt, err := New(u.Host, &Options{
+		Creds:  credentials.NewStaticV4("default", "secretkey", ""),
+		Region: "us-east-1",
+		BucketCreds: func(bucketName, method string) *credentials.Credentials {
+			switch {
+			case bucketName != "tenant":
+				return nil
+			case method == http.MethodGet || method == http.MethodHead:
+				return readOnly
+			default:
+				return tenant
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		do   func() error
+		want string
+	}{
+		{func() error { _, err := clnt.ListBuckets(ctx); return err }, "GET / default"},
+		{func() error { _, err := clnt.BucketExists(ctx, "other"); return err }, "HEAD /other/ default"},
+		{func() error { _, err := clnt.BucketExists(ctx, "tenant"); return err }, "HEAD /tenant/ readonly"},
+		{func() error { return clnt.RemoveObject(ctx, "tenant", "object", RemoveObjectOptions{}) }, "DELETE /tenant/object tenant"},
+	} {
+		test.do()
+		if got := <-keys; got != test.want {
+			t.Errorf("got request %q, want %q", got, test.want)
+		}
+	}
+}
+
 // Tests bucket policy types.
 func TestBucketPolicyTypes(t *testing.T) {
 	want := map[string]bool{
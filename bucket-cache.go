@@ -170,7 +170,7 @@ func (c *Client) getBucketLocationRequest(ctx context.Context, bucketName string
 	c.setUserAgent(req)
 
 	// Get credentials from the configured credentials provider.
-	value, err := c.credsProvider.GetWithContext(c.CredContext())
+	value, err := c.credsFor(bucketName, http.MethodGet).GetWithContext(c.CredContext())
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +209,15 @@ func (c *Client) getBucketLocationRequest(ctx context.Context, bucketName string
 	}
 
 	req.Header.Set("X-Amz-Content-Sha256", contentSha256)
+	if c.externalSigner != nil {
+		region := "us-east-1"
+		serviceType := signer.ServiceTypeS3
+		if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
+			region = getDefaultLocation(*c.endpointURL, c.region)
+			serviceType = signer.ServiceTypeS3Outposts
+		}
+		return signer.SignV4WithSigner(ctx, *req, c.externalSigner, accessKeyID, sessionToken, region, serviceType, nil)
+	}
 	if s3utils.IsAmazonOutpostsEndpoint(*c.endpointURL) {
 		region := getDefaultLocation(*c.endpointURL, c.region)
 		req = signer.SignV4Outposts(*req, accessKeyID, secretAccessKey, sessionToken, region)
@@ -142,7 +142,7 @@ func (c *Client) createSessionRequest(ctx context.Context, bucketName string, se
 	c.setUserAgent(req)
 
 	// Get credentials from the configured credentials provider.
-	value, err := c.credsProvider.GetWithContext(c.CredContext())
+	value, err := c.credsFor(bucketName, http.MethodGet).GetWithContext(c.CredContext())
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +177,9 @@ func (c *Client) createSessionRequest(ctx context.Context, bucketName string, se
 
 	req.Header.Set("X-Amz-Content-Sha256", contentSha256)
 	req.Header.Set("x-amz-create-session-mode", string(sessionMode))
+	if c.externalSigner != nil {
+		return signer.SignV4WithSigner(ctx, *req, c.externalSigner, accessKeyID, sessionToken, c.region, signer.ServiceTypeS3Express, nil)
+	}
 	req = signer.SignV4Express(*req, accessKeyID, secretAccessKey, sessionToken, c.region)
 	return req, nil
 }
@@ -26,8 +26,10 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/signer"
@@ -85,15 +87,26 @@ type STSAssumeRole struct {
 
 	// various options for this request.
 	Options STSAssumeRoleOptions
+
+	// source caches the credentials of Options.SourceProvider, it is
+	// created on first use if the provider was not made with
+	// NewSTSAssumeRole.
+	sourceMu sync.Mutex
+	source   *Credentials
 }
 
 // STSAssumeRoleOptions collection of various input options
 // to obtain AssumeRole credentials.
 type STSAssumeRoleOptions struct {
-	// Mandatory inputs.
+	// Mandatory inputs, unless SourceProvider is set.
 	AccessKey string
 	SecretKey string
 
+	// Optional provider of the credentials to assume the role with, such
+	// as the credentials of another role, instead of AccessKey, SecretKey
+	// and SessionToken.
+	SourceProvider Provider
+
 	SessionToken string // Optional if the first request is made with temporary credentials.
 	Policy       string // Optional to assign a policy to the assumed role
 
@@ -105,19 +118,40 @@ type STSAssumeRoleOptions struct {
 	RoleSessionName string
 	ExternalID      string
 
+	// Optional MFA device serial number and its current token code, when
+	// the trust policy of the role requires MFA. As token codes are only
+	// valid once, TokenCodeProvider, if set, is called for the token code
+	// of every request instead.
+	SerialNumber      string
+	TokenCode         string
+	TokenCodeProvider func() (string, error)
+
+	// Optional session tags, and the keys of the tags which are passed on
+	// to roles assumed with the returned credentials.
+	Tags              map[string]string
+	TransitiveTagKeys []string
+
+	// Optional source identity of the session, which is passed on to roles
+	// assumed with the returned credentials.
+	SourceIdentity string
+
 	TokenRevokeType string // Optional, used for token revokation (MinIO only extension)
 }
 
 // NewSTSAssumeRole returns a pointer to a new
 // Credentials object wrapping the STSAssumeRole.
 func NewSTSAssumeRole(stsEndpoint string, opts STSAssumeRoleOptions) (*Credentials, error) {
-	if opts.AccessKey == "" || opts.SecretKey == "" {
+	if opts.SourceProvider == nil && (opts.AccessKey == "" || opts.SecretKey == "") {
 		return nil, errors.New("AssumeRole credentials access/secretkey is mandatory")
 	}
-	return New(&STSAssumeRole{
+	m := &STSAssumeRole{
 		STSEndpoint: stsEndpoint,
 		Options:     opts,
-	}), nil
+	}
+	if opts.SourceProvider != nil {
+		m.source = New(opts.SourceProvider)
+	}
+	return New(m), nil
 }
 
 const defaultDurationSeconds = 3600
@@ -163,6 +197,25 @@ func getAssumeRoleCredentials(clnt *http.Client, endpoint string, opts STSAssume
 	if opts.ExternalID != "" {
 		v.Set("ExternalId", opts.ExternalID)
 	}
+	if opts.SerialNumber != "" {
+		v.Set("SerialNumber", opts.SerialNumber)
+		v.Set("TokenCode", opts.TokenCode)
+	}
+	tagKeys := make([]string, 0, len(opts.Tags))
+	for key := range opts.Tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+	for i, key := range tagKeys {
+		v.Set("Tags.member."+strconv.Itoa(i+1)+".Key", key)
+		v.Set("Tags.member."+strconv.Itoa(i+1)+".Value", opts.Tags[key])
+	}
+	for i, key := range opts.TransitiveTagKeys {
+		v.Set("TransitiveTagKeys.member."+strconv.Itoa(i+1), key)
+	}
+	if opts.SourceIdentity != "" {
+		v.Set("SourceIdentity", opts.SourceIdentity)
+	}
 	if opts.TokenRevokeType != "" {
 		v.Set("TokenRevokeType", opts.TokenRevokeType)
 	}
@@ -245,7 +298,37 @@ func (m *STSAssumeRole) RetrieveWithCredContext(cc *CredContext) (Value, error)
 		return Value{}, errors.New("STS endpoint unknown")
 	}
 
-	a, err := getAssumeRoleCredentials(client, stsEndpoint, m.Options)
+	opts := m.Options
+	if opts.SourceProvider != nil {
+		m.sourceMu.Lock()
+		if m.source == nil {
+			m.source = New(opts.SourceProvider)
+		}
+		sourceCreds := m.source
+		m.sourceMu.Unlock()
+
+		source, err := sourceCreds.GetWithContext(cc)
+		if err != nil {
+			return Value{}, err
+		}
+		opts.AccessKey = source.AccessKeyID
+		opts.SecretKey = source.SecretAccessKey
+		opts.SessionToken = source.SessionToken
+	}
+	if opts.SerialNumber != "" {
+		if opts.TokenCodeProvider != nil {
+			tokenCode, err := opts.TokenCodeProvider()
+			if err != nil {
+				return Value{}, err
+			}
+			opts.TokenCode = tokenCode
+		}
+		if opts.TokenCode == "" {
+			return Value{}, errors.New("AssumeRole token code is mandatory with a MFA serial number")
+		}
+	}
+
+	a, err := getAssumeRoleCredentials(client, stsEndpoint, opts)
 	if err != nil {
 		return Value{}, err
 	}
@@ -0,0 +1,124 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSTSAssumeRoleOptions(t *testing.T) {
+	var form url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests are signed with the source credentials.
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=source-key/") ||
+			r.Header.Get("X-Amz-Security-Token") != "source-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		form = r.PostForm
+		fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>assumed</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>`+
+			`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+	}))
+	defer ts.Close()
+
+	tokenCodes := []string{"111111", "222222"}
+	creds, err := NewSTSAssumeRole(ts.URL, STSAssumeRoleOptions{
+		SourceProvider: &Static{Value: Value{
+			AccessKeyID:     "source-key",
+			SecretAccessKey: "source-secret",
+			SessionToken:    "source-token",
+		}},
+		RoleARN:      "arn:aws:iam::123456789012:role/cross-account",
+		ExternalID:   "ext",
+		SerialNumber: "arn:aws:iam::123456789012:mfa/user",
+		TokenCodeProvider: func() (string, error) {
+			code := tokenCodes[0]
+			tokenCodes = tokenCodes[1:]
+			return code, nil
+		},
+		Tags:              map[string]string{"team": "storage", "env": "prod"},
+		TransitiveTagKeys: []string{"team"},
+		SourceIdentity:    "alice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, wantCode := range []string{"111111", "222222"} {
+		creds.Expire()
+		v, err := creds.GetWithContext(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.AccessKeyID != "assumed" {
+			t.Fatalf("unexpected credentials %+v", v)
+		}
+		want := url.Values{
+			"RoleArn":                    {"arn:aws:iam::123456789012:role/cross-account"},
+			"ExternalId":                 {"ext"},
+			"SerialNumber":               {"arn:aws:iam::123456789012:mfa/user"},
+			"TokenCode":                  {wantCode},
+			"Tags.member.1.Key":          {"env"},
+			"Tags.member.1.Value":        {"prod"},
+			"Tags.member.2.Key":          {"team"},
+			"Tags.member.2.Value":        {"storage"},
+			"TransitiveTagKeys.member.1": {"team"},
+			"SourceIdentity":             {"alice"},
+		}
+		for key, value := range want {
+			if form.Get(key) != value[0] {
+				t.Errorf("expected %s=%s, got %q", key, value[0], form.Get(key))
+			}
+		}
+	}
+
+	if _, err = NewSTSAssumeRole(ts.URL, STSAssumeRoleOptions{RoleARN: "arn"}); err == nil {
+		t.Fatal("expected error without source credentials")
+	}
+
+	// A MFA serial number requires a token code, which is only asked for
+	// with a serial number.
+	source := &Static{Value: Value{AccessKeyID: "source-key", SecretAccessKey: "source-secret", SessionToken: "source-token"}}
+	creds, err = NewSTSAssumeRole(ts.URL, STSAssumeRoleOptions{SourceProvider: source, SerialNumber: "arn:aws:iam::123456789012:mfa/user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = creds.GetWithContext(nil); err == nil {
+		t.Fatal("expected error without token code")
+	}
+	asked := false
+	creds, err = NewSTSAssumeRole(ts.URL, STSAssumeRoleOptions{
+		SourceProvider:    source,
+		TokenCodeProvider: func() (string, error) { asked = true; return "333333", nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = creds.GetWithContext(nil); err != nil || asked || form.Get("TokenCode") != "" {
+		t.Fatalf("expected no token code without serial number, got %v, asked %v, %q", err, asked, form.Get("TokenCode"))
+	}
+}
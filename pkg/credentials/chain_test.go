@@ -20,6 +20,7 @@ package credentials
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 type testCredProvider struct {
@@ -148,3 +149,31 @@ func TestChainProviderWithNoValidProvider(t *testing.T) {
 		}
 	}
 }
+
+func TestChainSourceAndExpiresAt(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).UTC()
+	c := NewChainCredentials([]Provider{
+		&credProvider{err: errors.New("no credentials")},
+		&testCredProvider{creds: Value{AccessKeyID: "AKID", SecretAccessKey: "SECRET", Expiration: expiration}},
+	})
+	if c.Source() != "" || !c.ExpiresAt().IsZero() {
+		t.Fatalf("expected no source and expiry before retrieval, got %q and %v", c.Source(), c.ExpiresAt())
+	}
+	if _, err := c.GetWithContext(nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.Source() != "testCredProvider" {
+		t.Errorf("expected source testCredProvider, got %q", c.Source())
+	}
+	if !c.ExpiresAt().Equal(expiration) {
+		t.Errorf("expected expiry %v, got %v", expiration, c.ExpiresAt())
+	}
+
+	s := NewStaticV4("AKID", "SECRET", "")
+	if _, err := s.GetWithContext(nil); err != nil {
+		t.Fatal(err)
+	}
+	if s.Source() != "Static" || !s.ExpiresAt().IsZero() {
+		t.Errorf("expected non-expiring Static source, got %q and %v", s.Source(), s.ExpiresAt())
+	}
+}
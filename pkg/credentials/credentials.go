@@ -19,6 +19,7 @@ package credentials
 
 import (
 	"net/http"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -153,6 +154,17 @@ type Credentials struct {
 	creds        Value
 	forceRefresh bool
 	provider     Provider
+
+	// retrievedAt is the time creds were retrieved at.
+	retrievedAt time.Time
+	// source is the provider which supplied creds.
+	source Provider
+	// refreshing is set while a background refresher is running, see
+	// StartRefresh.
+	refreshing bool
+	// retrieveMu serializes calls to the provider, which are made without
+	// holding the lock by the background refresher.
+	retrieveMu sync.Mutex
 }
 
 // New returns a pointer to a new Credentials with the provider set.
@@ -201,17 +213,73 @@ func (c *Credentials) GetWithContext(cc *CredContext) (Value, error) {
 	defer c.Unlock()
 
 	if c.isExpired() {
-		creds, err := c.provider.RetrieveWithCredContext(cc)
+		creds, source, err := c.retrieve(cc)
 		if err != nil {
 			return Value{}, err
 		}
-		c.creds = creds
-		c.forceRefresh = false
+		c.store(creds, source)
 	}
 
 	return c.creds, nil
 }
 
+// retrieve retrieves credentials from the provider, and returns the
+// provider which supplied them, the chain member for chains.
+func (c *Credentials) retrieve(cc *CredContext) (Value, Provider, error) {
+	c.retrieveMu.Lock()
+	defer c.retrieveMu.Unlock()
+
+	creds, err := c.provider.RetrieveWithCredContext(cc)
+	if err != nil {
+		return Value{}, nil, err
+	}
+	source := c.provider
+	for {
+		chain, ok := source.(*Chain)
+		if !ok || chain.curr == nil {
+			break
+		}
+		source = chain.curr
+	}
+	return creds, source, nil
+}
+
+// store caches creds retrieved from source, it is called with the lock
+// held.
+func (c *Credentials) store(creds Value, source Provider) {
+	c.creds = creds
+	c.source = source
+	c.retrievedAt = time.Now()
+	c.forceRefresh = false
+}
+
+// ExpiresAt returns the expiration of the cached credentials Value, it is
+// zero if the credentials do not expire or were not retrieved yet.
+func (c *Credentials) ExpiresAt() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.creds.Expiration
+}
+
+// Source returns the type name of the provider which supplied the cached
+// credentials Value, such as "EnvAWS" or "STSAssumeRole". For chains it is
+// the chain member which supplied the value. It is empty if the
+// credentials were not retrieved yet.
+func (c *Credentials) Source() string {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.source == nil {
+		return ""
+	}
+	t := reflect.TypeOf(c.source)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 // Expire expires the credentials and forces them to be retrieved on the
 // next call to Get().
 //
@@ -237,6 +305,16 @@ func (c *Credentials) IsExpired() bool {
 }
 
 // isExpired helper method wrapping the definition of expired credentials.
+// While a background refresher is running the credentials are served
+// until their actual expiration.
 func (c *Credentials) isExpired() bool {
-	return c.forceRefresh || c.provider.IsExpired()
+	if c.forceRefresh {
+		return true
+	}
+	if c.refreshing && !c.creds.Expiration.IsZero() && time.Now().Before(c.creds.Expiration) {
+		return false
+	}
+	c.retrieveMu.Lock()
+	defer c.retrieveMu.Unlock()
+	return c.provider.IsExpired()
 }
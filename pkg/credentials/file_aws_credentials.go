@@ -20,9 +20,12 @@ package credentials
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +44,12 @@ type externalProcessCredentials struct {
 // A FileAWSCredentials retrieves credentials from the current user's home
 // directory, and keeps track if those credentials are expired.
 //
+// Profiles are read from the shared credentials file and the shared config
+// file, with values of the credentials file taking precedence. Profiles
+// with a role_arn assume the role with STS, using the credentials of their
+// source_profile, credential_source or web_identity_token_file, such that
+// roles can be chained across profiles.
+//
 // Profile ini file example: $HOME/.aws/credentials
 type FileAWSCredentials struct {
 	Expiry
@@ -53,11 +62,31 @@ type FileAWSCredentials struct {
 	// Windows:   "%USERPROFILE%\.aws\credentials"
 	Filename string
 
+	// Path to the shared config file.
+	//
+	// If empty will look for "AWS_CONFIG_FILE" env variable. If the env
+	// value is empty will default to "config" in the ".aws" directory of the
+	// current user's home directory. A missing config file is ignored.
+	ConfigFilename string
+
 	// AWS Profile to extract credentials from the shared credentials file. If empty
 	// will default to environment variable "AWS_PROFILE" or "default" if
 	// environment variable is also not set.
 	Profile string
 
+	// Optional http Client to use when assuming roles with STS
+	// (overrides default client in CredContext)
+	Client *http.Client
+
+	// Optional STS endpoint to assume roles with, defaults to the AWS STS
+	// endpoint of the region of the profile.
+	STSEndpoint string
+
+	// MFATokenProvider returns the current token code of the MFA device
+	// with the given serial number, it is required for profiles with an
+	// mfa_serial.
+	MFATokenProvider func(serialNumber string) (string, error)
+
 	// retrieved states if the credentials have been successfully retrieved.
 	retrieved bool
 }
@@ -71,7 +100,7 @@ func NewFileAWSCredentials(filename, profile string) *Credentials {
 	})
 }
 
-func (p *FileAWSCredentials) retrieve() (Value, error) {
+func (p *FileAWSCredentials) retrieve(cc *CredContext) (Value, error) {
 	if p.Filename == "" {
 		p.Filename = os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
 		if p.Filename == "" {
@@ -82,6 +111,14 @@ func (p *FileAWSCredentials) retrieve() (Value, error) {
 			p.Filename = filepath.Join(homeDir, ".aws", "credentials")
 		}
 	}
+	if p.ConfigFilename == "" {
+		p.ConfigFilename = os.Getenv("AWS_CONFIG_FILE")
+		if p.ConfigFilename == "" {
+			if homeDir, err := os.UserHomeDir(); err == nil {
+				p.ConfigFilename = filepath.Join(homeDir, ".aws", "config")
+			}
+		}
+	}
 	if p.Profile == "" {
 		p.Profile = os.Getenv("AWS_PROFILE")
 		if p.Profile == "" {
@@ -91,21 +128,162 @@ func (p *FileAWSCredentials) retrieve() (Value, error) {
 
 	p.retrieved = false
 
-	iniProfile, err := loadProfile(p.Filename, p.Profile)
+	config, err := loadAWSSharedConfig(p.Filename, p.ConfigFilename)
+	if err != nil {
+		return Value{}, err
+	}
+	if cc == nil {
+		cc = defaultCredContext
+	}
+	v, err := p.resolveProfile(cc, config, p.Profile, make(map[string]bool))
 	if err != nil {
 		return Value{}, err
 	}
 
-	// Default to empty string if not found.
-	id := iniProfile.Key("aws_access_key_id")
-	// Default to empty string if not found.
-	secret := iniProfile.Key("aws_secret_access_key")
-	// Default to empty string if not found.
-	token := iniProfile.Key("aws_session_token")
+	p.retrieved = true
+	if v.Expiration.IsZero() {
+		p.expiration = time.Time{}
+	} else {
+		p.SetExpiration(v.Expiration, DefaultExpiryWindow)
+	}
+	return v, nil
+}
+
+// resolveProfile returns the credentials of a profile, assuming its role
+// if it has one. visited holds the profiles of the chain so far.
+func (p *FileAWSCredentials) resolveProfile(cc *CredContext, config *awsSharedConfig, name string, visited map[string]bool) (Value, error) {
+	if visited[name] {
+		return Value{}, fmt.Errorf("profile %q: circular source_profile reference", name)
+	}
+	visited[name] = true
+
+	profile, err := config.profile(name)
+	if err != nil {
+		return Value{}, err
+	}
+	roleARN := profile["role_arn"]
+	if roleARN == "" {
+		return profileCredentials(profile)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = cc.Client
+	}
+	if client == nil {
+		client = defaultCredContext.Client
+	}
+
+	region := profile["region"]
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	endpoint := p.STSEndpoint
+	if endpoint == "" {
+		endpoint = getDefaultSTSEndpoint(region)
+	}
+
+	roleSessionName := profile["role_session_name"]
+	if roleSessionName == "" {
+		roleSessionName = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	var durationSeconds int
+	if s := profile["duration_seconds"]; s != "" {
+		if durationSeconds, err = strconv.Atoi(s); err != nil {
+			return Value{}, fmt.Errorf("profile %q: invalid duration_seconds %q", name, s)
+		}
+	}
+
+	if tokenFile := profile["web_identity_token_file"]; tokenFile != "" {
+		a, err := getWebIdentityCredentials(client, endpoint, roleARN, roleSessionName, "", func() (*WebIdentityToken, error) {
+			token, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return nil, err
+			}
+			return &WebIdentityToken{Token: string(token), Expiry: durationSeconds}, nil
+		}, "")
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{
+			AccessKeyID:     a.Result.Credentials.AccessKey,
+			SecretAccessKey: a.Result.Credentials.SecretKey,
+			SessionToken:    a.Result.Credentials.SessionToken,
+			Expiration:      a.Result.Credentials.Expiration,
+			SignerType:      SignatureV4,
+		}, nil
+	}
+
+	var source Value
+	switch {
+	case profile["source_profile"] == name:
+		// A profile may assume its role with its own static credentials.
+		source, err = profileCredentials(profile)
+	case profile["source_profile"] != "":
+		source, err = p.resolveProfile(cc, config, profile["source_profile"], visited)
+	case profile["credential_source"] == "Environment":
+		source, err = (&EnvAWS{}).retrieve()
+	case profile["credential_source"] == "Ec2InstanceMetadata", profile["credential_source"] == "EcsContainer":
+		source, err = (&IAM{Client: client}).RetrieveWithCredContext(cc)
+	case profile["credential_source"] != "":
+		return Value{}, fmt.Errorf("profile %q: unsupported credential_source %q", name, profile["credential_source"])
+	default:
+		return Value{}, fmt.Errorf("profile %q: role_arn requires source_profile, credential_source or web_identity_token_file", name)
+	}
+	if err != nil {
+		return Value{}, err
+	}
+	if source.AccessKeyID == "" || source.SecretAccessKey == "" {
+		return Value{}, fmt.Errorf("profile %q: no source credentials to assume role %s", name, roleARN)
+	}
+
+	location := region
+	if location == "" {
+		location = "us-east-1"
+	}
+	opts := STSAssumeRoleOptions{
+		AccessKey:       source.AccessKeyID,
+		SecretKey:       source.SecretAccessKey,
+		SessionToken:    source.SessionToken,
+		Location:        location,
+		DurationSeconds: durationSeconds,
+		RoleARN:         roleARN,
+		RoleSessionName: roleSessionName,
+		ExternalID:      profile["external_id"],
+	}
+	if serial := profile["mfa_serial"]; serial != "" {
+		if p.MFATokenProvider == nil {
+			return Value{}, fmt.Errorf("profile %q: mfa_serial requires an MFATokenProvider", name)
+		}
+		tokenCode, err := p.MFATokenProvider(serial)
+		if err != nil {
+			return Value{}, err
+		}
+		opts.SerialNumber = serial
+		opts.TokenCode = tokenCode
+	}
+	a, err := getAssumeRoleCredentials(client, endpoint, opts)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{
+		AccessKeyID:     a.Result.Credentials.AccessKey,
+		SecretAccessKey: a.Result.Credentials.SecretKey,
+		SessionToken:    a.Result.Credentials.SessionToken,
+		Expiration:      a.Result.Credentials.Expiration,
+		SignerType:      SignatureV4,
+	}, nil
+}
 
+// profileCredentials returns the static or credential_process credentials
+// of a profile.
+func profileCredentials(profile map[string]string) (Value, error) {
 	// If credential_process is defined, obtain credentials by executing
 	// the external process
-	credentialProcess := strings.TrimSpace(iniProfile.Key("credential_process").String())
+	credentialProcess := strings.TrimSpace(profile["credential_process"])
 	if credentialProcess != "" {
 		args := strings.Fields(credentialProcess)
 		if len(args) <= 1 {
@@ -121,8 +299,6 @@ func (p *FileAWSCredentials) retrieve() (Value, error) {
 		if err != nil {
 			return Value{}, err
 		}
-		p.retrieved = true
-		p.SetExpiration(externalProcessCredentials.Expiration, DefaultExpiryWindow)
 		return Value{
 			AccessKeyID:     externalProcessCredentials.AccessKeyID,
 			SecretAccessKey: externalProcessCredentials.SecretAccessKey,
@@ -131,11 +307,11 @@ func (p *FileAWSCredentials) retrieve() (Value, error) {
 			SignerType:      SignatureV4,
 		}, nil
 	}
-	p.retrieved = true
+	// Default to empty string if not found.
 	return Value{
-		AccessKeyID:     id.String(),
-		SecretAccessKey: secret.String(),
-		SessionToken:    token.String(),
+		AccessKeyID:     profile["aws_access_key_id"],
+		SecretAccessKey: profile["aws_secret_access_key"],
+		SessionToken:    profile["aws_session_token"],
 		SignerType:      SignatureV4,
 	}, nil
 }
@@ -143,25 +319,74 @@ func (p *FileAWSCredentials) retrieve() (Value, error) {
 // Retrieve reads and extracts the shared credentials from the current
 // users home directory.
 func (p *FileAWSCredentials) Retrieve() (Value, error) {
-	return p.retrieve()
+	return p.retrieve(nil)
 }
 
-// RetrieveWithCredContext is like Retrieve(), cred context is no-op for File credentials
-func (p *FileAWSCredentials) RetrieveWithCredContext(_ *CredContext) (Value, error) {
-	return p.retrieve()
+// RetrieveWithCredContext is like Retrieve(), the cred context is only used
+// to assume roles.
+func (p *FileAWSCredentials) RetrieveWithCredContext(cc *CredContext) (Value, error) {
+	return p.retrieve(cc)
 }
 
-// loadProfiles loads from the file pointed to by shared credentials filename for profile.
-// The credentials retrieved from the profile will be returned or error. Error will be
-// returned if it fails to read from the file, or the data is invalid.
-func loadProfile(filename, profile string) (*ini.Section, error) {
-	config, err := ini.Load(filename)
-	if err != nil {
-		return nil, err
+// awsSharedConfig holds the AWS shared credentials and config files.
+type awsSharedConfig struct {
+	credentials *ini.File
+	config      *ini.File
+
+	// credentialsErr is the error loading the credentials file, returned
+	// if a profile is found in neither file.
+	credentialsErr error
+}
+
+// loadAWSSharedConfig loads the shared credentials and config files, files
+// that do not exist are skipped.
+func loadAWSSharedConfig(credentialsFile, configFile string) (*awsSharedConfig, error) {
+	c := &awsSharedConfig{}
+	var err error
+	if c.credentials, err = ini.Load(credentialsFile); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		c.credentialsErr = err
 	}
-	iniProfile, err := config.GetSection(profile)
-	if err != nil {
-		return nil, err
+	if configFile != "" {
+		if c.config, err = ini.Load(configFile); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// profile returns the keys of a profile, the config file names profiles
+// "profile <name>" except for the default profile.
+func (c *awsSharedConfig) profile(name string) (map[string]string, error) {
+	keys := make(map[string]string)
+	found := false
+	if c.config != nil {
+		section := "profile " + name
+		if name == "default" && !c.config.HasSection(section) {
+			section = name
+		}
+		if s, err := c.config.GetSection(section); err == nil {
+			found = true
+			for _, k := range s.Keys() {
+				keys[k.Name()] = k.String()
+			}
+		}
+	}
+	if c.credentials != nil {
+		if s, err := c.credentials.GetSection(name); err == nil {
+			found = true
+			for _, k := range s.Keys() {
+				keys[k.Name()] = k.String()
+			}
+		}
+	}
+	if !found {
+		if c.credentialsErr != nil {
+			return nil, c.credentialsErr
+		}
+		return nil, fmt.Errorf("profile %q does not exist", name)
 	}
-	return iniProfile, nil
+	return keys, nil
 }
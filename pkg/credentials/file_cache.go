@@ -0,0 +1,265 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// fileCacheLockTimeout is how long to wait for the lock of another
+	// process before retrieving the credentials without it.
+	fileCacheLockTimeout = 30 * time.Second
+
+	// fileCacheStaleLock is the age after which a lock is considered
+	// abandoned by a crashed process.
+	fileCacheStaleLock = time.Minute
+
+	fileCacheLockPoll = 50 * time.Millisecond
+)
+
+// fileCacheEntry is the content of a cache file.
+type fileCacheEntry struct {
+	Retrieved time.Time
+	Value     Value
+}
+
+// A FileCache caches the credentials of a provider, such as STSAssumeRole,
+// LDAPIdentity or STSWebIdentity, in a file readable only by the current
+// user, such that short-lived processes using the same provider reuse the
+// credentials until they are close to expiration instead of retrieving new
+// ones. Processes refreshing the same credentials at once are serialized
+// with a lock file, such that only one of them calls the provider.
+//
+// Credentials without expiration are not cached.
+type FileCache struct {
+	Expiry
+
+	// Provider retrieves the credentials on a cache miss.
+	Provider Provider
+
+	// Key optionally overrides the key identifying the credentials of the
+	// provider, which is derived from the parameters of STSAssumeRole,
+	// LDAPIdentity and STSWebIdentity providers, such as the STS endpoint,
+	// role and user name. It is mandatory with other providers. Different
+	// parameters must result in different keys. The file is named after
+	// the hash of the key, so it may contain secrets.
+	Key string
+
+	// Dir holds the cache files, defaults to "minio-go/credentials" in the
+	// user's cache directory.
+	Dir string
+
+	// Window is the time before their expiration after which cached
+	// credentials are no longer used, defaults to 20% of their lifetime.
+	Window time.Duration
+}
+
+// NewFileCache returns a pointer to a new Credentials object caching the
+// credentials of provider on disk, under a key derived from the parameters
+// of the provider.
+func NewFileCache(provider Provider) *Credentials {
+	return New(&FileCache{
+		Provider: provider,
+	})
+}
+
+// fileCacheKey derives the cache key of the credentials of provider from
+// its parameters, it returns false if the provider is not supported.
+func fileCacheKey(provider Provider) (string, bool) {
+	var params []any
+	switch p := provider.(type) {
+	case *Static:
+		params = []any{"Static", p.AccessKeyID, p.SessionToken}
+	case *STSAssumeRole:
+		opts := p.Options
+		params = []any{
+			"AssumeRole", p.STSEndpoint, opts.RoleARN, opts.RoleSessionName, opts.ExternalID,
+			opts.AccessKey, opts.Policy, opts.DurationSeconds, opts.Location, opts.SerialNumber,
+			opts.Tags, opts.TransitiveTagKeys, opts.SourceIdentity,
+		}
+		if opts.SourceProvider != nil {
+			source, ok := fileCacheKey(opts.SourceProvider)
+			if !ok {
+				return "", false
+			}
+			params = append(params, source)
+		}
+	case *LDAPIdentity:
+		params = []any{
+			"LDAPIdentity", p.STSEndpoint, p.LDAPUsername, p.Policy, p.RequestedExpiry, p.ConfigName,
+		}
+	case *STSWebIdentity:
+		// The subject of the web identity token is not known before
+		// retrieving it, different identities assuming the same role
+		// need a Key.
+		params = []any{
+			"WebIdentity", p.STSEndpoint, p.RoleARN, p.roleSessionName, p.Policy,
+		}
+	default:
+		return "", false
+	}
+	key, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// RetrieveWithCredContext returns the cached credentials, or retrieves and
+// caches new credentials from the provider if they are missing or close to
+// expiration.
+func (f *FileCache) RetrieveWithCredContext(cc *CredContext) (Value, error) {
+	if f.Provider == nil {
+		return Value{}, errors.New("file cache provider is not set")
+	}
+	key := f.Key
+	if key == "" {
+		var ok bool
+		if key, ok = fileCacheKey(f.Provider); !ok {
+			return Value{}, errors.New("file cache key is not set and cannot be derived from the provider")
+		}
+	}
+	if f.Dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return Value{}, err
+		}
+		f.Dir = filepath.Join(cacheDir, "minio-go", "credentials")
+	}
+	sum := sha256.Sum256([]byte(key))
+	filename := filepath.Join(f.Dir, hex.EncodeToString(sum[:])+".json")
+
+	if v, validUntil, ok := f.load(filename); ok {
+		f.SetExpiration(validUntil, 0)
+		return v, nil
+	}
+
+	if err := os.MkdirAll(f.Dir, 0o700); err != nil {
+		return Value{}, err
+	}
+	unlock := lockFile(filename + ".lock")
+	defer unlock()
+
+	// Another process may have refreshed the credentials meanwhile.
+	if v, validUntil, ok := f.load(filename); ok {
+		f.SetExpiration(validUntil, 0)
+		return v, nil
+	}
+
+	v, err := f.Provider.RetrieveWithCredContext(cc)
+	if err != nil {
+		return Value{}, err
+	}
+	if v.Expiration.IsZero() {
+		f.expiration = time.Time{}
+		return v, nil
+	}
+	entry := fileCacheEntry{Retrieved: time.Now(), Value: v}
+	if err = writeFileCache(filename, entry); err != nil {
+		return Value{}, err
+	}
+	f.SetExpiration(f.validUntil(entry), 0)
+	return v, nil
+}
+
+// Retrieve returns the cached credentials or retrieves new ones.
+func (f *FileCache) Retrieve() (Value, error) {
+	return f.RetrieveWithCredContext(nil)
+}
+
+// load returns the cached credentials of filename if they are still
+// valid, and the time until which they are.
+func (f *FileCache) load(filename string) (Value, time.Time, bool) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Value{}, time.Time{}, false
+	}
+	var entry fileCacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return Value{}, time.Time{}, false
+	}
+	validUntil := f.validUntil(entry)
+	if !time.Now().Before(validUntil) {
+		return Value{}, time.Time{}, false
+	}
+	return entry.Value, validUntil, true
+}
+
+// validUntil returns the time until which the credentials of entry are
+// used.
+func (f *FileCache) validUntil(entry fileCacheEntry) time.Time {
+	window := f.Window
+	if window <= 0 {
+		window = time.Duration(float64(entry.Value.Expiration.Sub(entry.Retrieved)) * (1 - defaultExpiryWindow))
+	}
+	return entry.Value.Expiration.Add(-window)
+}
+
+// writeFileCache atomically replaces the cache file, such that readers
+// never see partial content.
+func writeFileCache(filename string, entry fileCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// Temporary files are created with mode 0600.
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+// lockFile acquires a lock across processes by exclusively creating
+// filename, and returns the function releasing it. Locks of crashed
+// processes are broken after fileCacheStaleLock, and the lock is given up
+// after fileCacheLockTimeout, such that a stuck process delays but does
+// not block others.
+func lockFile(filename string) (unlock func()) {
+	deadline := time.Now().Add(fileCacheLockTimeout)
+	for {
+		f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(filename) }
+		}
+		if !os.IsExist(err) || time.Now().After(deadline) {
+			return func() {}
+		}
+		if info, err := os.Stat(filename); err == nil && time.Since(info.ModTime()) > fileCacheStaleLock {
+			os.Remove(filename)
+			continue
+		}
+		time.Sleep(fileCacheLockPoll)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	var retrieved atomic.Int32
+	lifetime := time.Hour
+
+	// Every cache instance stands in for a separate process.
+	newCache := func(key string) *FileCache {
+		return &FileCache{
+			Provider: &expiringProvider{lifetime: lifetime, counter: &retrieved},
+			Key:      key,
+			Dir:      dir,
+		}
+	}
+
+	var wg sync.WaitGroup
+	values := make([]Value, 8)
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := newCache("sts|role|alice").Retrieve()
+			if err != nil {
+				t.Error(err)
+			}
+			values[i] = v
+		}()
+	}
+	wg.Wait()
+	if retrieved.Load() != 1 {
+		t.Fatalf("expected a single retrieval, got %d", retrieved.Load())
+	}
+	for _, v := range values {
+		if v.AccessKeyID != values[0].AccessKeyID || !v.Expiration.Equal(values[0].Expiration) {
+			t.Fatalf("expected cached credentials %+v, got %+v", values[0], v)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one cache file, got %v, %v", files, err)
+	}
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(files[0]); err != nil || info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected cache file mode 0600, got %v, %v", info.Mode(), err)
+		}
+	}
+
+	// Other parameters are cached separately.
+	c := newCache("sts|role|bob")
+	if _, err = c.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if retrieved.Load() != 2 || c.IsExpired() {
+		t.Fatalf("expected a new retrieval, got %d", retrieved.Load())
+	}
+
+	// Credentials within the window are refreshed.
+	lifetime = 100 * time.Millisecond
+	c = newCache("sts|role|carol")
+	c.Window = 50 * time.Millisecond
+	if _, err = c.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if !c.IsExpired() {
+		t.Fatal("expected credentials within the window to be expired")
+	}
+	c = newCache("sts|role|carol")
+	c.Window = 50 * time.Millisecond
+	if _, err = c.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if retrieved.Load() != 4 {
+		t.Fatalf("expected the credentials to be refreshed, got %d retrievals", retrieved.Load())
+	}
+}
+
+func TestFileCacheKey(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>`+
+			`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`, r.PostForm.Get("RoleArn"))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	newCache := func(roleARN string) *FileCache {
+		return &FileCache{
+			Provider: &STSAssumeRole{
+				STSEndpoint: ts.URL,
+				Options:     STSAssumeRoleOptions{AccessKey: "access", SecretKey: "secret", RoleARN: roleARN},
+			},
+			Dir: dir,
+		}
+	}
+	for _, roleARN := range []string{"role-a", "role-b", "role-a", "role-b"} {
+		v, err := newCache(roleARN).Retrieve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.AccessKeyID != roleARN {
+			t.Fatalf("expected credentials of %s, got %s", roleARN, v.AccessKeyID)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Fatalf("expected one request per role, got %d", requests.Load())
+	}
+
+	if _, err := (&FileCache{Provider: &expiringProvider{}, Dir: dir}).Retrieve(); err == nil {
+		t.Fatal("expected error without key for an unsupported provider")
+	}
+}
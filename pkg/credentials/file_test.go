@@ -18,9 +18,15 @@
 package credentials
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -212,3 +218,139 @@ func TestFileMinioClient(t *testing.T) {
 		t.Error("Should be expired if not loaded")
 	}
 }
+
+func TestFileAWSConfig(t *testing.T) {
+	os.Clearenv()
+
+	// The fake STS issues credentials named after the caller and the role.
+	var requests []url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		requests = append(requests, r.PostForm)
+		role := path.Base(r.PostForm.Get("RoleArn"))
+		action := r.PostForm.Get("Action")
+		caller := r.PostForm.Get("WebIdentityToken")
+		if action == "AssumeRole" {
+			auth := strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=")
+			caller, _, _ = strings.Cut(auth, "/")
+		}
+		fmt.Fprintf(w, `<%sResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><%sResult><Credentials>`+
+			`<AccessKeyId>%s@%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>`+
+			`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></%sResult></%sResponse>`,
+			action, action, role, caller, action, action)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		t.Helper()
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	credentialsFile := writeFile("credentials", `
+[base]
+aws_access_key_id = base-key
+aws_secret_access_key = base-secret
+
+[self]
+aws_access_key_id = self-key
+aws_secret_access_key = self-secret
+role_arn = arn:aws:iam::123456789012:role/self
+source_profile = self
+`)
+	configFile := writeFile("config", `
+[default]
+region = us-west-2
+
+[profile hop1]
+role_arn = arn:aws:iam::123456789012:role/hop1
+source_profile = base
+external_id = ext-1
+
+[profile hop2]
+role_arn = arn:aws:iam::123456789012:role/hop2
+source_profile = hop1
+mfa_serial = arn:aws:iam::123456789012:mfa/user
+duration_seconds = 7200
+
+[profile env]
+role_arn = arn:aws:iam::123456789012:role/env
+credential_source = Environment
+
+[profile web]
+role_arn = arn:aws:iam::123456789012:role/web
+web_identity_token_file = `+writeFile("token", "web-token")+`
+
+[profile loop1]
+role_arn = arn:aws:iam::123456789012:role/loop1
+source_profile = loop2
+
+[profile loop2]
+role_arn = arn:aws:iam::123456789012:role/loop2
+source_profile = loop1
+`)
+	t.Setenv("AWS_ACCESS_KEY_ID", "env-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+
+	retrieve := func(profile string) (Value, error) {
+		p := &FileAWSCredentials{
+			Filename:       credentialsFile,
+			ConfigFilename: configFile,
+			Profile:        profile,
+			STSEndpoint:    ts.URL,
+			MFATokenProvider: func(serial string) (string, error) {
+				return "123456", nil
+			},
+		}
+		return New(p).GetWithContext(defaultCredContext)
+	}
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{profile: "base", want: "base-key"},
+		{profile: "self", want: "self@self-key"},
+		{profile: "hop1", want: "hop1@base-key"},
+		{profile: "hop2", want: "hop2@hop1@base-key"},
+		{profile: "env", want: "env@env-key"},
+		{profile: "web", want: "web@web-token"},
+	}
+	for _, tt := range tests {
+		v, err := retrieve(tt.profile)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.profile, err)
+		}
+		if v.AccessKeyID != tt.want {
+			t.Errorf("%s: expected access key %q, got %q", tt.profile, tt.want, v.AccessKeyID)
+		}
+	}
+
+	// Resolving hop2 assumes hop1 and then hop2.
+	requests = nil
+	if _, err := retrieve("hop2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 STS requests, got %d", len(requests))
+	}
+	if got := requests[0].Get("ExternalId"); got != "ext-1" {
+		t.Errorf("expected external ID ext-1, got %q", got)
+	}
+	hop2 := requests[1]
+	if hop2.Get("SerialNumber") != "arn:aws:iam::123456789012:mfa/user" || hop2.Get("TokenCode") != "123456" || hop2.Get("DurationSeconds") != "7200" {
+		t.Errorf("unexpected hop2 request %v", hop2)
+	}
+
+	if _, err := retrieve("loop1"); err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Errorf("expected circular reference error, got %v", err)
+	}
+	if _, err := retrieve("missing"); err == nil {
+		t.Error("expected error for missing profile")
+	}
+}
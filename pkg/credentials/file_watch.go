@@ -0,0 +1,232 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultFileWatchInterval is the default interval between checks of a
+// watched file.
+const defaultFileWatchInterval = 10 * time.Second
+
+// A FileWatch retrieves credentials from a file which is rewritten when the
+// credentials are rotated, such as a Kubernetes secret mount or the output
+// of a Vault agent, and expires them when the file changes.
+//
+// The file is checked at most once per Interval, by its modification time
+// and size and then by the hash of its content. If the changed file cannot
+// be read or parsed the last good credentials are kept and the error is
+// reported to OnError and LastError.
+type FileWatch struct {
+	// Path to the credentials file.
+	Filename string
+
+	// Interval between checks of the file, defaults to 10 seconds.
+	Interval time.Duration
+
+	// Parse returns the credentials of the file content, defaults to
+	// ParseCredentialsFile.
+	Parse func(data []byte) (Value, error)
+
+	// OnError, if set, is called with errors reading or parsing the file.
+	OnError func(err error)
+
+	value     Value
+	retrieved bool
+	hash      [sha256.Size]byte
+	modTime   time.Time
+	size      int64
+	checked   time.Time
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewFileWatch returns a pointer to a new Credentials object wrapping the
+// FileWatch provider of filename.
+func NewFileWatch(filename string) *Credentials {
+	return New(&FileWatch{
+		Filename: filename,
+	})
+}
+
+// RetrieveWithCredContext reads the credentials from the file, no-op input
+// of Cred Context.
+func (w *FileWatch) RetrieveWithCredContext(_ *CredContext) (Value, error) {
+	return w.retrieve()
+}
+
+// Retrieve reads the credentials from the file.
+func (w *FileWatch) Retrieve() (Value, error) {
+	return w.retrieve()
+}
+
+func (w *FileWatch) retrieve() (Value, error) {
+	w.checked = time.Now()
+	info, err := os.Stat(w.Filename)
+	if err != nil {
+		return w.fail(err)
+	}
+	data, err := os.ReadFile(w.Filename)
+	if err != nil {
+		return w.fail(err)
+	}
+	// The content is remembered even if it is invalid, such that it is
+	// not parsed again until it changes.
+	w.hash = sha256.Sum256(data)
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	parse := w.Parse
+	if parse == nil {
+		parse = ParseCredentialsFile
+	}
+	v, err := parse(data)
+	if err != nil {
+		return w.fail(err)
+	}
+
+	w.value = v
+	w.retrieved = true
+	w.mu.Lock()
+	w.lastErr = nil
+	w.mu.Unlock()
+	return v, nil
+}
+
+// fail reports err and returns the last good credentials, or err if there
+// are none.
+func (w *FileWatch) fail(err error) (Value, error) {
+	w.mu.Lock()
+	w.lastErr = err
+	w.mu.Unlock()
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+	if !w.retrieved {
+		return Value{}, err
+	}
+	return w.value, nil
+}
+
+// IsExpired returns if the credentials have not been retrieved yet, the
+// file changed since, or the Expiration of the credentials is less than one
+// Interval away.
+func (w *FileWatch) IsExpired() bool {
+	if !w.retrieved {
+		return true
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultFileWatchInterval
+	}
+	if exp := w.value.Expiration; !exp.IsZero() && time.Now().Add(interval).After(exp) {
+		return true
+	}
+	if time.Since(w.checked) < interval {
+		return false
+	}
+	w.checked = time.Now()
+
+	// A missing file is usually replaced shortly, such as with atomic
+	// renames, so the credentials are kept.
+	info, err := os.Stat(w.Filename)
+	if err != nil {
+		return false
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+	data, err := os.ReadFile(w.Filename)
+	if err != nil {
+		return false
+	}
+	if sha256.Sum256(data) == w.hash {
+		w.modTime, w.size = info.ModTime(), info.Size()
+		return false
+	}
+	return true
+}
+
+// LastError returns the error of the last attempt to read the file, or nil
+// if it succeeded.
+func (w *FileWatch) LastError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastErr
+}
+
+// fileCredentials are the JSON credentials of a credentials file, in the
+// format of MinIO or AWS.
+type fileCredentials struct {
+	AccessKey       string `json:"accessKey"`
+	SecretKey       string `json:"secretKey"`
+	SessionToken    string `json:"sessionToken"`
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Expiration      time.Time
+}
+
+// ParseCredentialsFile parses the content of a credentials file, either a
+// JSON object with accessKey, secretKey and optionally sessionToken, or
+// their AWS equivalents AccessKeyId, SecretAccessKey and SessionToken, or
+// the access key, secret key and optionally session token separated by
+// whitespace, such as on separate lines.
+func ParseCredentialsFile(data []byte) (Value, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		var c fileCredentials
+		if err := json.Unmarshal(data, &c); err != nil {
+			return Value{}, err
+		}
+		if c.AccessKey == "" {
+			c.AccessKey, c.SecretKey = c.AccessKeyID, c.SecretAccessKey
+		}
+		if c.AccessKey == "" || c.SecretKey == "" {
+			return Value{}, errors.New("credentials file has no access and secret key")
+		}
+		return Value{
+			AccessKeyID:     c.AccessKey,
+			SecretAccessKey: c.SecretKey,
+			SessionToken:    c.SessionToken,
+			Expiration:      c.Expiration,
+			SignerType:      SignatureV4,
+		}, nil
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 && len(fields) != 3 {
+		return Value{}, errors.New("credentials file must contain an access key, a secret key and optionally a session token")
+	}
+	v := Value{
+		AccessKeyID:     fields[0],
+		SecretAccessKey: fields[1],
+		SignerType:      SignatureV4,
+	}
+	if len(fields) == 3 {
+		v.SessionToken = fields[2]
+	}
+	return v, nil
+}
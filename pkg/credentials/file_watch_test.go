@@ -0,0 +1,132 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileWatch(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "credentials")
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(filename, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get := func(c *Credentials) string {
+		t.Helper()
+		v, err := c.GetWithContext(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.AccessKeyID
+	}
+
+	write("access1\nsecret1\n")
+	var errs int
+	w := &FileWatch{
+		Filename: filename,
+		Interval: time.Nanosecond,
+		OnError:  func(error) { errs++ },
+	}
+	c := New(w)
+	if got := get(c); got != "access1" {
+		t.Fatalf("expected access1, got %s", got)
+	}
+
+	// Unchanged content keeps the credentials, even if touched.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filename, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if c.IsExpired() {
+		t.Fatal("expected touched file not to expire the credentials")
+	}
+
+	// Rotated credentials are picked up.
+	write(`{"accessKey":"access2","secretKey":"secret2","sessionToken":"token2"}`)
+	if !c.IsExpired() {
+		t.Fatal("expected rotated file to expire the credentials")
+	}
+	if got := get(c); got != "access2" {
+		t.Fatalf("expected access2, got %s", got)
+	}
+
+	// Invalid content keeps the last good credentials.
+	write(`{"accessKey":`)
+	if got := get(c); got != "access2" || w.LastError() == nil || errs != 1 {
+		t.Fatalf("expected last good credentials and an error, got %s, %v, %d errors", got, w.LastError(), errs)
+	}
+	if c.IsExpired() {
+		t.Fatal("expected invalid content not to be parsed again")
+	}
+
+	write(`{"AccessKeyId":"access3","SecretAccessKey":"secret3"}`)
+	if got := get(c); got != "access3" || w.LastError() != nil {
+		t.Fatalf("expected access3, got %s, %v", got, w.LastError())
+	}
+
+	// Credentials expire at their Expiration even if the file is not
+	// rewritten.
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	write(`{"accessKey":"access4","secretKey":"secret4","Expiration":"` + expired + `"}`)
+	if got := get(c); got != "access4" {
+		t.Fatalf("expected access4, got %s", got)
+	}
+	if !c.IsExpired() {
+		t.Fatal("expected credentials past their expiration to expire")
+	}
+
+	// Without good credentials the error is returned.
+	if _, err := NewFileWatch(filepath.Join(t.TempDir(), "missing")).GetWithContext(nil); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestParseCredentialsFile(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Value
+		wantErr bool
+	}{
+		{data: "key secret", want: Value{AccessKeyID: "key", SecretAccessKey: "secret"}},
+		{data: "key\nsecret\ntoken\n", want: Value{AccessKeyID: "key", SecretAccessKey: "secret", SessionToken: "token"}},
+		{data: `{"accessKey":"key","secretKey":"secret"}`, want: Value{AccessKeyID: "key", SecretAccessKey: "secret"}},
+		{data: `{"AccessKeyId":"key","SecretAccessKey":"secret","SessionToken":"token"}`, want: Value{AccessKeyID: "key", SecretAccessKey: "secret", SessionToken: "token"}},
+		{data: "key", wantErr: true},
+		{data: `{"accessKey":"key"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		v, err := ParseCredentialsFile([]byte(tt.data))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: want error %v, got %v", tt.data, tt.wantErr, err)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		tt.want.SignerType = SignatureV4
+		if v != tt.want {
+			t.Errorf("%q: expected %+v, got %+v", tt.data, tt.want, v)
+		}
+	}
+}
@@ -94,6 +94,19 @@ func NewIAM(endpoint string) *Credentials {
 	})
 }
 
+// getDefaultSTSEndpoint returns the AWS STS endpoint of a region, or the
+// global endpoint if the region is empty.
+func getDefaultSTSEndpoint(region string) string {
+	switch {
+	case region == "":
+		return DefaultSTSRoleEndpoint
+	case strings.HasPrefix(region, "cn-"):
+		return "https://sts." + region + ".amazonaws.com.cn"
+	default:
+		return "https://sts." + region + ".amazonaws.com"
+	}
+}
+
 // RetrieveWithCredContext is like Retrieve with Cred Context
 func (m *IAM) RetrieveWithCredContext(cc *CredContext) (Value, error) {
 	if cc == nil {
@@ -156,15 +169,7 @@ func (m *IAM) RetrieveWithCredContext(cc *CredContext) (Value, error) {
 	switch {
 	case identityFile != "":
 		if len(endpoint) == 0 {
-			if region != "" {
-				if strings.HasPrefix(region, "cn-") {
-					endpoint = "https://sts." + region + ".amazonaws.com.cn"
-				} else {
-					endpoint = "https://sts." + region + ".amazonaws.com"
-				}
-			} else {
-				endpoint = DefaultSTSRoleEndpoint
-			}
+			endpoint = getDefaultSTSEndpoint(region)
 		}
 
 		creds := &STSWebIdentity{
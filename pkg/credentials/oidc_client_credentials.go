@@ -0,0 +1,324 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// clientAssertionType is the client assertion type of private_key_jwt
+	// client authentication, see RFC 7523.
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	// clientAssertionLifetime is the lifetime of private_key_jwt
+	// assertions.
+	clientAssertionLifetime = 5 * time.Minute
+
+	// maxOIDCTokenRefreshMargin bounds how long before their expiry
+	// tokens are renewed.
+	maxOIDCTokenRefreshMargin = time.Minute
+
+	// defaultOIDCTokenLifetime is the lifetime of tokens issued without
+	// expires_in whose expiry is not known from a JWT exp claim either.
+	defaultOIDCTokenLifetime = 5 * time.Minute
+)
+
+// OIDCClientCredentials fetches access tokens from the token endpoint of an
+// OpenID Connect provider, such as Keycloak or Dex, with the OAuth2 client
+// credentials grant and caches them until shortly before they expire.
+//
+// The client authenticates with its ClientSecret, or with a private_key_jwt
+// assertion signed by PrivateKey. Tokens are passed to STS with
+// WebIdentityToken and ClientGrantsToken:
+//
+//	oidc := &credentials.OIDCClientCredentials{
+//	    TokenURL:     "https://keycloak.example.com/realms/minio/protocol/openid-connect/token",
+//	    ClientID:     "backup-service",
+//	    ClientSecret: "...",
+//	}
+//	creds, err := credentials.NewSTSWebIdentity("https://minio.example.com", oidc.WebIdentityToken)
+type OIDCClientCredentials struct {
+	// Optional http Client to use when connecting to the token endpoint.
+	Client *http.Client
+
+	// TokenURL is the token endpoint of the provider.
+	TokenURL string
+
+	// ClientID and ClientSecret of the client, the secret is sent with
+	// HTTP basic authentication.
+	ClientID     string
+	ClientSecret string
+
+	// PrivateKey, if set, signs a private_key_jwt assertion authenticating
+	// the client instead of ClientSecret. RSA, ECDSA and Ed25519 keys are
+	// supported. KeyID is the optional "kid" of the key.
+	PrivateKey crypto.Signer
+	KeyID      string
+
+	// Optional scopes of the token, and further parameters of the token
+	// request such as an audience.
+	Scopes []string
+	Params url.Values
+
+	mu        sync.Mutex
+	token     string
+	expiry    time.Time
+	refreshAt time.Time
+}
+
+// oidcTokenResponse is the response of a token endpoint.
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token returns a valid token and its expiry, fetching a new token if the
+// cached token is about to expire. The ID token is returned if the provider
+// issues one, the access token otherwise.
+func (o *OIDCClientCredentials) Token() (string, time.Time, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && time.Now().Before(o.refreshAt) {
+		return o.token, o.expiry, nil
+	}
+
+	form := url.Values{}
+	for k, v := range o.Params {
+		form[k] = v
+	}
+	form.Set("grant_type", "client_credentials")
+	if len(o.Scopes) > 0 {
+		form.Set("scope", strings.Join(o.Scopes, " "))
+	}
+	if o.PrivateKey != nil {
+		assertion, err := o.clientAssertion()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		form.Set("client_id", o.ClientID)
+		form.Set("client_assertion_type", clientAssertionType)
+		form.Set("client_assertion", assertion)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, o.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.PrivateKey == nil {
+		req.SetBasicAuth(url.QueryEscape(o.ClientID), url.QueryEscape(o.ClientSecret))
+	}
+
+	client := o.Client
+	if client == nil {
+		client = defaultCredContext.Client
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer closeResponse(resp)
+
+	var tr oidcTokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&tr); err != nil && resp.StatusCode == http.StatusOK {
+		return "", time.Time{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if tr.Error != "" {
+			return "", time.Time{}, fmt.Errorf("token request failed: %s: %s", tr.Error, tr.ErrorDescription)
+		}
+		return "", time.Time{}, fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	token := tr.IDToken
+	if token == "" {
+		token = tr.AccessToken
+	}
+	if token == "" {
+		return "", time.Time{}, errors.New("token response has no token")
+	}
+	// expires_in is optional, the exp claim of JWT tokens tells the
+	// expiry otherwise.
+	now := time.Now()
+	lifetime := time.Duration(tr.ExpiresIn) * time.Second
+	if tr.ExpiresIn <= 0 {
+		lifetime = defaultOIDCTokenLifetime
+		if exp, ok := jwtExpiry(token); ok && exp.After(now) {
+			lifetime = exp.Sub(now)
+		}
+	}
+	expiry := now.Add(lifetime)
+
+	o.token, o.expiry = token, expiry
+	o.refreshAt = expiry.Add(-min(lifetime/10, maxOIDCTokenRefreshMargin))
+	return token, expiry, nil
+}
+
+// WebIdentityToken returns a token for STSWebIdentity, it can be passed as
+// the token function of NewSTSWebIdentity.
+func (o *OIDCClientCredentials) WebIdentityToken() (*WebIdentityToken, error) {
+	token, expiry, err := o.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &WebIdentityToken{Token: token, Expiry: tokenExpirySeconds(expiry)}, nil
+}
+
+// ClientGrantsToken returns a token for STSClientGrants, it can be passed
+// as the token function of NewSTSClientGrants.
+func (o *OIDCClientCredentials) ClientGrantsToken() (*ClientGrantsToken, error) {
+	token, expiry, err := o.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &ClientGrantsToken{Token: token, Expiry: tokenExpirySeconds(expiry)}, nil
+}
+
+// tokenExpirySeconds returns the remaining lifetime of a token in seconds.
+func tokenExpirySeconds(expiry time.Time) int {
+	return max(int(time.Until(expiry).Seconds()), 0)
+}
+
+// jwtExpiry returns the time of the exp claim of a JWT, the signature is
+// not verified.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp == "" {
+		return time.Time{}, false
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
+
+// clientAssertion returns a private_key_jwt assertion signed by the
+// private key of the client.
+func (o *OIDCClientCredentials) clientAssertion() (string, error) {
+	var (
+		alg  string
+		hash crypto.Hash
+	)
+	switch key := o.PrivateKey.Public().(type) {
+	case *rsa.PublicKey:
+		alg, hash = "RS256", crypto.SHA256
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().BitSize {
+		case 256:
+			alg, hash = "ES256", crypto.SHA256
+		case 384:
+			alg, hash = "ES384", crypto.SHA384
+		default:
+			return "", fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		alg = "EdDSA"
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	fields := map[string]string{"alg": alg, "typ": "JWT"}
+	if o.KeyID != "" {
+		fields["kid"] = o.KeyID
+	}
+	header, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss": o.ClientID,
+		"sub": o.ClientID,
+		"aud": o.TokenURL,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	var signature []byte
+	switch hash {
+	case crypto.SHA256:
+		sum := sha256.Sum256([]byte(signingInput))
+		signature, err = o.PrivateKey.Sign(rand.Reader, sum[:], hash)
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(signingInput))
+		signature, err = o.PrivateKey.Sign(rand.Reader, sum[:], hash)
+	default:
+		// Ed25519 signs the message itself.
+		signature, err = o.PrivateKey.Sign(rand.Reader, []byte(signingInput), crypto.Hash(0))
+	}
+	if err != nil {
+		return "", err
+	}
+	if key, ok := o.PrivateKey.Public().(*ecdsa.PublicKey); ok {
+		// JWS encodes ECDSA signatures as the fixed size r and s instead
+		// of ASN.1.
+		var sig struct{ R, S *big.Int }
+		if _, err = asn1.Unmarshal(signature, &sig); err != nil {
+			return "", err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		sig.R.FillBytes(signature[:size])
+		sig.S.FillBytes(signature[size:])
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
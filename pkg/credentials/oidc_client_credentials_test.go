@@ -0,0 +1,204 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verifyClientAssertion verifies a private_key_jwt assertion of client
+// signed by the key of pub.
+func verifyClientAssertion(t *testing.T, assertion, client, audience string, pub crypto.PublicKey) bool {
+	t.Helper()
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	sum := sha256.Sum256(signingInput)
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], sig) != nil {
+			return false
+		}
+	case *ecdsa.PublicKey:
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		if len(sig) != 64 || !ecdsa.Verify(pub, sum[:], r, s) {
+			return false
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return false
+		}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+	var claims struct{ Iss, Sub, Aud string }
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return false
+	}
+	return claims.Iss == client && claims.Sub == client && claims.Aud == audience
+}
+
+func TestOIDCClientCredentials(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]crypto.Signer{"rsa-client": rsaKey, "ec-client": ecKey, "ed-client": edKey}
+
+	var issued int
+	var tokenURL string
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		client, secret, ok := r.BasicAuth()
+		if !ok {
+			client = r.PostForm.Get("client_id")
+			key := keys[client]
+			if key == nil || r.PostForm.Get("client_assertion_type") != clientAssertionType ||
+				!verifyClientAssertion(t, r.PostForm.Get("client_assertion"), client, tokenURL, key.Public()) {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad assertion"}`)
+				return
+			}
+		} else if client != "secret-client" || secret != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad secret"}`)
+			return
+		}
+		issued++
+		fmt.Fprintf(w, `{"access_token":"%s-token-%d","token_type":"Bearer","expires_in":3600}`, client, issued)
+	}))
+	defer idp.Close()
+	tokenURL = idp.URL + "/token"
+
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleWithWebIdentityResult><Credentials>`+
+			`<AccessKeyId>%s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken>`+
+			`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`,
+			r.PostForm.Get("WebIdentityToken"))
+	}))
+	defer sts.Close()
+
+	// Client secret, the token is cached across STS requests.
+	oidc := &OIDCClientCredentials{TokenURL: tokenURL, ClientID: "secret-client", ClientSecret: "s3cr3t"}
+	creds, err := NewSTSWebIdentity(sts.URL, oidc.WebIdentityToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		creds.Expire()
+		v, err := creds.GetWithContext(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v.AccessKeyID != "secret-client-token-1" {
+			t.Fatalf("unexpected credentials %+v", v)
+		}
+	}
+	cg, err := oidc.ClientGrantsToken()
+	if err != nil || cg.Token != "secret-client-token-1" || cg.Expiry < 3500 {
+		t.Fatalf("unexpected client grants token %+v, %v", cg, err)
+	}
+
+	// private_key_jwt assertions.
+	for client, key := range keys {
+		oidc := &OIDCClientCredentials{TokenURL: tokenURL, ClientID: client, PrivateKey: key, KeyID: "key-1"}
+		token, _, err := oidc.Token()
+		if err != nil {
+			t.Fatalf("%s: %v", client, err)
+		}
+		if !strings.HasPrefix(token, client+"-token-") {
+			t.Fatalf("%s: unexpected token %s", client, token)
+		}
+	}
+
+	oidc = &OIDCClientCredentials{TokenURL: tokenURL, ClientID: "secret-client", ClientSecret: "wrong"}
+	if _, _, err = oidc.Token(); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("expected invalid_client error, got %v", err)
+	}
+
+	// Tokens without expires_in are cached until their exp claim, or for
+	// the default lifetime of opaque tokens.
+	exp := time.Now().Add(time.Hour).Unix()
+	jwt := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, `{"sub":"svc","exp":%d}`, exp)) + ".sig"
+	var fetched int
+	noExpiry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		token := jwt
+		if r.URL.Path == "/opaque" {
+			token = "opaque-token"
+		}
+		fmt.Fprintf(w, `{"access_token":"%s","token_type":"Bearer"}`, token)
+	}))
+	defer noExpiry.Close()
+
+	oidc = &OIDCClientCredentials{TokenURL: noExpiry.URL + "/jwt", ClientID: "secret-client", ClientSecret: "s3cr3t"}
+	for range 3 {
+		token, expiry, err := oidc.Token()
+		if err != nil || token != jwt || expiry.Unix() != exp {
+			t.Fatalf("unexpected token %s expiring at %v, %v", token, expiry, err)
+		}
+	}
+	oidc = &OIDCClientCredentials{TokenURL: noExpiry.URL + "/opaque", ClientID: "secret-client", ClientSecret: "s3cr3t"}
+	for range 3 {
+		_, expiry, err := oidc.Token()
+		if err != nil || time.Until(expiry) < defaultOIDCTokenLifetime-time.Minute {
+			t.Fatalf("unexpected expiry %v, %v", expiry, err)
+		}
+	}
+	if fetched != 2 {
+		t.Fatalf("expected 2 token requests, got %d", fetched)
+	}
+}
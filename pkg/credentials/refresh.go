@@ -0,0 +1,207 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	// defaultRefreshFraction is the fraction of the lifetime of the
+	// credentials after which they are refreshed in the background, it
+	// is below defaultExpiryWindow such that Get never has to refresh.
+	defaultRefreshFraction = 0.7
+
+	// defaultRefreshJitter is the fraction of the lifetime by which
+	// refreshes are randomly moved earlier.
+	defaultRefreshJitter = 0.1
+
+	defaultRefreshMinBackoff = time.Second
+	defaultRefreshMaxBackoff = time.Minute
+)
+
+// RefreshOptions configures the background refresher of StartRefresh.
+type RefreshOptions struct {
+	// LifetimeFraction is the fraction of the lifetime of the credentials
+	// after which they are refreshed, defaults to 0.7.
+	LifetimeFraction float64
+
+	// Jitter is the fraction of the lifetime by which refreshes are
+	// randomly moved earlier, such that many clients sharing a provider
+	// do not refresh at the same time. Defaults to 0.1, negative values
+	// disable jitter.
+	Jitter float64
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// retries of failed refreshes, default to one second and one minute.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// CredContext is passed to the provider, defaults to a context using
+	// http.DefaultClient.
+	CredContext *CredContext
+
+	// OnRefresh, if set, is called from the refresher after every refresh
+	// attempt.
+	OnRefresh func(RefreshEvent)
+}
+
+// RefreshEvent describes an attempt of the background refresher.
+type RefreshEvent struct {
+	// Time of the attempt.
+	Time time.Time
+
+	// Attempt counts the consecutive attempts, it is 1 unless previous
+	// attempts failed.
+	Attempt int
+
+	// Err is the error of a failed attempt, the previous credentials are
+	// still served until they expire.
+	Err error
+
+	// Expiration of the refreshed credentials.
+	Expiration time.Time
+
+	// Next is the time of the next attempt, it is zero if the credentials
+	// do not expire and the refresher stopped.
+	Next time.Time
+}
+
+// StartRefresh starts refreshing the credentials in the background before
+// they expire, such that Get does not have to wait for the provider. The
+// credentials are refreshed after a fraction of their lifetime and served
+// until their expiration while a refresh is pending or failing. Failed
+// refreshes are retried with exponential backoff.
+//
+// Credentials without expiration are not refreshed. Only one refresher
+// should run at a time, it runs until the returned stop function is called.
+func (c *Credentials) StartRefresh(opts RefreshOptions) (stop func()) {
+	if opts.LifetimeFraction <= 0 || opts.LifetimeFraction > 1 {
+		opts.LifetimeFraction = defaultRefreshFraction
+	}
+	if opts.Jitter == 0 {
+		opts.Jitter = defaultRefreshJitter
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultRefreshMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(defaultRefreshMaxBackoff, opts.MinBackoff)
+	}
+	if opts.CredContext == nil {
+		opts.CredContext = defaultCredContext
+	}
+
+	c.Lock()
+	c.refreshing = true
+	c.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.refreshLoop(ctx, opts)
+	}()
+	return func() {
+		cancel()
+		<-done
+		c.Lock()
+		c.refreshing = false
+		c.Unlock()
+	}
+}
+
+// refreshLoop refreshes the credentials until ctx is canceled or the
+// credentials do not expire.
+func (c *Credentials) refreshLoop(ctx context.Context, opts RefreshOptions) {
+	c.Lock()
+	var wait time.Duration
+	if !c.forceRefresh {
+		if c.creds.Expiration.IsZero() {
+			c.Unlock()
+			return
+		}
+		wait = refreshDelay(c.retrievedAt, c.creds.Expiration, opts)
+	}
+	c.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	backoff := opts.MinBackoff
+	attempt := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		attempt++
+		event := RefreshEvent{Time: time.Now(), Attempt: attempt}
+		creds, err := c.refresh(opts.CredContext)
+		switch {
+		case err != nil:
+			event.Err = err
+			wait = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+			backoff = min(2*backoff, opts.MaxBackoff)
+		case creds.Expiration.IsZero():
+			if opts.OnRefresh != nil {
+				opts.OnRefresh(event)
+			}
+			return
+		default:
+			event.Expiration = creds.Expiration
+			wait = refreshDelay(event.Time, creds.Expiration, opts)
+			backoff = opts.MinBackoff
+			attempt = 0
+		}
+		event.Next = time.Now().Add(wait)
+		if opts.OnRefresh != nil {
+			opts.OnRefresh(event)
+		}
+		timer.Reset(wait)
+	}
+}
+
+// refresh retrieves new credentials from the provider without blocking
+// Get, which serves the previous credentials meanwhile.
+func (c *Credentials) refresh(cc *CredContext) (Value, error) {
+	creds, source, err := c.retrieve(cc)
+	if err != nil {
+		return Value{}, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.store(creds, source)
+	return creds, nil
+}
+
+// refreshDelay returns the time until credentials retrieved at retrievedAt
+// and expiring at expiration are to be refreshed.
+func refreshDelay(retrievedAt, expiration time.Time, opts RefreshOptions) time.Duration {
+	lifetime := float64(expiration.Sub(retrievedAt))
+	offset := lifetime * opts.LifetimeFraction
+	if opts.Jitter > 0 {
+		offset -= lifetime * opts.Jitter * rand.Float64()
+	}
+	return max(time.Until(retrievedAt.Add(time.Duration(offset))), 0)
+}
@@ -0,0 +1,135 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// expiringProvider returns credentials which expire after lifetime, failing
+// while fail is set. Retrievals are counted in counter if set.
+type expiringProvider struct {
+	Expiry
+	lifetime  time.Duration
+	retrieved atomic.Int32
+	counter   *atomic.Int32
+	fail      atomic.Bool
+}
+
+func (p *expiringProvider) Retrieve() (Value, error) {
+	return p.RetrieveWithCredContext(nil)
+}
+
+func (p *expiringProvider) RetrieveWithCredContext(_ *CredContext) (Value, error) {
+	if p.fail.Load() {
+		return Value{}, errors.New("sts unavailable")
+	}
+	n := p.retrieved.Add(1)
+	if p.counter != nil {
+		n = p.counter.Add(1)
+	}
+	expiration := time.Now().Add(p.lifetime)
+	p.SetExpiration(expiration, -1)
+	return Value{
+		AccessKeyID:     "access" + strconv.Itoa(int(n)),
+		SecretAccessKey: "secret",
+		Expiration:      expiration,
+	}, nil
+}
+
+func TestCredentialsStartRefresh(t *testing.T) {
+	p := &expiringProvider{lifetime: time.Second}
+	c := New(p)
+
+	events := make(chan RefreshEvent, 16)
+	stop := c.StartRefresh(RefreshOptions{
+		LifetimeFraction: 0.5,
+		Jitter:           -1,
+		MinBackoff:       20 * time.Millisecond,
+		MaxBackoff:       40 * time.Millisecond,
+		OnRefresh:        func(e RefreshEvent) { events <- e },
+	})
+	defer stop()
+
+	next := func() RefreshEvent {
+		t.Helper()
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for refresh")
+			return RefreshEvent{}
+		}
+	}
+
+	// Initial credentials are retrieved right away.
+	if e := next(); e.Err != nil || e.Attempt != 1 || e.Expiration.IsZero() {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if v, err := c.GetWithContext(nil); err != nil || v.AccessKeyID != "access1" {
+		t.Fatalf("unexpected credentials %v, %v", v, err)
+	}
+
+	// Renewed at half of their lifetime.
+	e := next()
+	if e.Err != nil || p.retrieved.Load() != 2 {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	expiration := e.Expiration
+
+	// Failed refreshes are retried while the old credentials are served,
+	// even once the provider considers them expired.
+	p.fail.Store(true)
+	if e = next(); e.Err == nil || e.Attempt != 1 {
+		t.Fatalf("expected failed attempt, got %+v", e)
+	}
+	if e = next(); e.Err == nil || e.Attempt != 2 {
+		t.Fatalf("expected failed retry, got %+v", e)
+	}
+	time.Sleep(time.Until(expiration.Add(-150 * time.Millisecond)))
+	if v, err := c.GetWithContext(nil); err != nil || v.AccessKeyID != "access2" {
+		t.Fatalf("expected old credentials, got %v, %v", v, err)
+	}
+	if !p.IsExpired() || c.IsExpired() {
+		t.Fatal("expected credentials to be served past the provider expiry window")
+	}
+
+	p.fail.Store(false)
+	for e = next(); e.Err != nil; e = next() {
+	}
+	if v, err := c.GetWithContext(nil); err != nil || v.AccessKeyID != "access3" {
+		t.Fatalf("expected refreshed credentials, got %v, %v", v, err)
+	}
+}
+
+func TestCredentialsStartRefreshStatic(t *testing.T) {
+	c := NewStaticV4("access", "secret", "")
+	if _, err := c.GetWithContext(nil); err != nil {
+		t.Fatal(err)
+	}
+	var refreshed atomic.Bool
+	stop := c.StartRefresh(RefreshOptions{OnRefresh: func(RefreshEvent) { refreshed.Store(true) }})
+	stop()
+	if refreshed.Load() {
+		t.Fatal("expected static credentials not to be refreshed")
+	}
+}
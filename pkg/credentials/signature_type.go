@@ -30,6 +30,7 @@ const (
 	SignatureV2
 	SignatureV4Streaming
 	SignatureAnonymous // Anonymous signature signifies, no signature.
+	SignatureV4A       // Asymmetric signature v4a, required by multi-region access points.
 )
 
 // IsV2 - is signature SignatureV2?
@@ -47,6 +48,11 @@ func (s SignatureType) IsStreamingV4() bool {
 	return s == SignatureV4Streaming
 }
 
+// IsV4A - is signature SignatureV4A?
+func (s SignatureType) IsV4A() bool {
+	return s == SignatureV4A
+}
+
 // IsAnonymous - is signature empty?
 func (s SignatureType) IsAnonymous() bool {
 	return s == SignatureAnonymous
@@ -61,6 +67,8 @@ func (s SignatureType) String() string {
 		return "S3v4"
 	} else if s.IsStreamingV4() {
 		return "S3v4Streaming"
+	} else if s.IsV4A() {
+		return "S3v4A"
 	}
 	return "Anonymous"
 }
@@ -72,6 +80,8 @@ func parseSignatureType(str string) SignatureType {
 		return SignatureV2
 	} else if strings.EqualFold(str, "S3v4Streaming") {
 		return SignatureV4Streaming
+	} else if strings.EqualFold(str, "S3v4A") {
+		return SignatureV4A
 	}
 	return SignatureAnonymous
 }
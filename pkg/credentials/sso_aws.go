@@ -0,0 +1,258 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-ini/ini"
+)
+
+// ssoCachedToken is an access token cached by "aws sso login".
+type ssoCachedToken struct {
+	AccessToken string `json:"accessToken"`
+	// ExpiresAt is in RFC 3339, or in the legacy format of
+	// ssoLegacyTimeFormat.
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// ssoLegacyTimeFormat is the expiresAt format of tokens cached by older
+// versions of the AWS CLI, such as "2019-11-14T04:22:51UTC".
+const ssoLegacyTimeFormat = "2006-01-02T15:04:05UTC"
+
+// expiry returns the time the token expires.
+func (t ssoCachedToken) expiry() (time.Time, error) {
+	expiry, err := time.Parse(time.RFC3339, t.ExpiresAt)
+	if err != nil {
+		if legacy, lerr := time.Parse(ssoLegacyTimeFormat, t.ExpiresAt); lerr == nil {
+			return legacy, nil
+		}
+	}
+	return expiry, err
+}
+
+// ssoRoleCredentialsResponse is the response of GetRoleCredentials.
+type ssoRoleCredentialsResponse struct {
+	RoleCredentials struct {
+		AccessKeyID     string `json:"accessKeyId"`
+		SecretAccessKey string `json:"secretAccessKey"`
+		SessionToken    string `json:"sessionToken"`
+		// Expiration in milliseconds since the epoch.
+		Expiration int64 `json:"expiration"`
+	} `json:"roleCredentials"`
+}
+
+// A SSO retrieves credentials of an AWS IAM Identity Center (SSO) profile
+// of the shared config file, using the access token cached by
+// "aws sso login", and keeps track if those credentials are expired.
+//
+// The profile names the account and role with sso_account_id and
+// sso_role_name, and the portal with either an sso_session, whose section
+// holds sso_start_url and sso_region, or the legacy sso_start_url and
+// sso_region keys.
+type SSO struct {
+	Expiry
+
+	// Optional http Client to use when connecting to the SSO portal
+	// (overrides default client in CredContext)
+	Client *http.Client
+
+	// Path to the shared config file.
+	//
+	// If empty will look for "AWS_CONFIG_FILE" env variable. If the env
+	// value is empty will default to "config" in the ".aws" directory of the
+	// current user's home directory.
+	ConfigFilename string
+
+	// AWS Profile to extract the SSO configuration from. If empty will
+	// default to environment variable "AWS_PROFILE" or "default" if
+	// environment variable is also not set.
+	Profile string
+
+	// Directory of the cached access tokens, defaults to ".aws/sso/cache"
+	// in the current user's home directory.
+	CacheDir string
+
+	// Optional portal endpoint, defaults to the AWS portal of the
+	// sso_region.
+	Endpoint string
+}
+
+// NewSSO returns a pointer to a new Credentials object wrapping the SSO
+// provider of the profile.
+func NewSSO(profile string) *Credentials {
+	return New(&SSO{
+		Profile: profile,
+	})
+}
+
+// RetrieveWithCredContext retrieves the role credentials from the SSO
+// portal with the cached access token.
+func (s *SSO) RetrieveWithCredContext(cc *CredContext) (Value, error) {
+	if cc == nil {
+		cc = defaultCredContext
+	}
+	client := s.Client
+	if client == nil {
+		client = cc.Client
+	}
+	if client == nil {
+		client = defaultCredContext.Client
+	}
+
+	if s.ConfigFilename == "" {
+		s.ConfigFilename = os.Getenv("AWS_CONFIG_FILE")
+	}
+	if s.ConfigFilename == "" || s.CacheDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return Value{}, err
+		}
+		if s.ConfigFilename == "" {
+			s.ConfigFilename = filepath.Join(homeDir, ".aws", "config")
+		}
+		if s.CacheDir == "" {
+			s.CacheDir = filepath.Join(homeDir, ".aws", "sso", "cache")
+		}
+	}
+	if s.Profile == "" {
+		s.Profile = os.Getenv("AWS_PROFILE")
+		if s.Profile == "" {
+			s.Profile = "default"
+		}
+	}
+
+	configFile, err := ini.Load(s.ConfigFilename)
+	if err != nil {
+		return Value{}, err
+	}
+	config := &awsSharedConfig{config: configFile}
+	profile, err := config.profile(s.Profile)
+	if err != nil {
+		return Value{}, err
+	}
+	accountID, roleName := profile["sso_account_id"], profile["sso_role_name"]
+	if accountID == "" || roleName == "" {
+		return Value{}, fmt.Errorf("profile %q: sso_account_id and sso_role_name are required", s.Profile)
+	}
+
+	// The token is cached under the name of the session, or the start URL
+	// of legacy profiles.
+	region, cacheKey := profile["sso_region"], profile["sso_start_url"]
+	if session := profile["sso_session"]; session != "" {
+		section, err := configFile.GetSection("sso-session " + session)
+		if err != nil {
+			return Value{}, fmt.Errorf("profile %q: sso-session %q does not exist", s.Profile, session)
+		}
+		region, cacheKey = section.Key("sso_region").String(), session
+	}
+	if region == "" || cacheKey == "" {
+		return Value{}, fmt.Errorf("profile %q: no SSO session or start URL and region configured", s.Profile)
+	}
+
+	token, err := s.cachedToken(cacheKey)
+	if err != nil {
+		return Value{}, err
+	}
+
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://portal.sso." + region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return Value{}, err
+	}
+	u.Path = "/federation/credentials"
+	u.RawQuery = url.Values{"account_id": {accountID}, "role_name": {roleName}}.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Value{}, err
+	}
+	req.Header.Set("x-amz-sso_bearer_token", token.AccessToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		return Value{}, err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return Value{}, fmt.Errorf("SSO GetRoleCredentials failed: %s: %s", resp.Status, body)
+	}
+
+	var creds ssoRoleCredentialsResponse
+	if err = json.NewDecoder(resp.Body).Decode(&creds); err != nil {
+		return Value{}, err
+	}
+	expiration := time.UnixMilli(creds.RoleCredentials.Expiration).UTC()
+	s.SetExpiration(expiration, DefaultExpiryWindow)
+
+	return Value{
+		AccessKeyID:     creds.RoleCredentials.AccessKeyID,
+		SecretAccessKey: creds.RoleCredentials.SecretAccessKey,
+		SessionToken:    creds.RoleCredentials.SessionToken,
+		Expiration:      expiration,
+		SignerType:      SignatureV4,
+	}, nil
+}
+
+// Retrieve retrieves the role credentials from the SSO portal.
+func (s *SSO) Retrieve() (Value, error) {
+	return s.RetrieveWithCredContext(nil)
+}
+
+// ssoCacheFile returns the name of the file caching the access token of
+// key, it is named after the SHA-1 of key.
+func ssoCacheFile(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// cachedToken returns the valid cached access token of key.
+func (s *SSO) cachedToken(key string) (ssoCachedToken, error) {
+	data, err := os.ReadFile(filepath.Join(s.CacheDir, ssoCacheFile(key)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ssoCachedToken{}, errors.New("no cached SSO access token, run 'aws sso login'")
+		}
+		return ssoCachedToken{}, err
+	}
+	var token ssoCachedToken
+	if err = json.Unmarshal(data, &token); err != nil {
+		return ssoCachedToken{}, err
+	}
+	expiry, err := token.expiry()
+	if err != nil {
+		return ssoCachedToken{}, err
+	}
+	if token.AccessToken == "" || !time.Now().Before(expiry) {
+		return ssoCachedToken{}, errors.New("cached SSO access token expired, run 'aws sso login'")
+	}
+	return token, nil
+}
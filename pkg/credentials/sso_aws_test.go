@@ -0,0 +1,114 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSSO(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).Truncate(time.Millisecond).UTC()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/federation/credentials" || r.Header.Get("x-amz-sso_bearer_token") != "sso-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		fmt.Fprintf(w, `{"roleCredentials":{"accessKeyId":"%s-%s","secretAccessKey":"secret","sessionToken":"token","expiration":%d}}`,
+			q.Get("account_id"), q.Get("role_name"), expiration.UnixMilli())
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	if err := os.WriteFile(configFile, []byte(`
+[profile dev]
+sso_session = corp
+sso_account_id = 111111111111
+sso_role_name = Developer
+
+[profile legacy]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+sso_account_id = 222222222222
+sso_role_name = ReadOnly
+
+[profile expired]
+sso_start_url = https://expired.awsapps.com/start
+sso_region = us-east-1
+sso_account_id = 333333333333
+sso_role_name = ReadOnly
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = eu-west-1
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	if err := os.Mkdir(cacheDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeToken := func(name, token, expiresAt string) {
+		t.Helper()
+		data := fmt.Sprintf(`{"accessToken":%q,"expiresAt":%q}`, token, expiresAt)
+		if err := os.WriteFile(filepath.Join(cacheDir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeToken(ssoCacheFile("corp"), "sso-token", time.Now().Add(time.Hour).Format(time.RFC3339))
+	// Older AWS CLI versions cache tokens with a legacy time format.
+	writeToken(ssoCacheFile("https://corp.awsapps.com/start"), "sso-token", time.Now().UTC().Add(time.Hour).Format(ssoLegacyTimeFormat))
+	writeToken(ssoCacheFile("https://expired.awsapps.com/start"), "sso-token", time.Now().UTC().Add(-time.Minute).Format(ssoLegacyTimeFormat))
+
+	newSSO := func(profile string) *SSO {
+		return &SSO{ConfigFilename: configFile, Profile: profile, CacheDir: cacheDir, Endpoint: ts.URL}
+	}
+
+	// SSO plugs into the chain after providers without credentials.
+	creds := NewChainCredentials([]Provider{&EnvAWS{}, newSSO("dev")})
+	os.Clearenv()
+	v, err := creds.GetWithContext(defaultCredContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKeyID != "111111111111-Developer" || !v.Expiration.Equal(expiration) {
+		t.Fatalf("unexpected credentials %+v", v)
+	}
+
+	p := newSSO("legacy")
+	if v, err = p.Retrieve(); err != nil || v.AccessKeyID != "222222222222-ReadOnly" {
+		t.Fatalf("unexpected credentials %+v, %v", v, err)
+	}
+	if p.IsExpired() {
+		t.Fatal("expected credentials not to be expired")
+	}
+
+	if _, err = newSSO("expired").Retrieve(); err == nil {
+		t.Fatal("expected expired token to be rejected")
+	}
+	if _, err = newSSO("missing").Retrieve(); err == nil {
+		t.Fatal("expected missing profile to be rejected")
+	}
+}
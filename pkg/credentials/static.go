@@ -37,6 +37,12 @@ func NewStaticV4(id, secret, token string) *Credentials {
 	return NewStatic(id, secret, token, SignatureV4)
 }
 
+// NewStaticV4A is similar to NewStaticV2 with similar considerations,
+// requests are signed with signature v4a.
+func NewStaticV4A(id, secret, token string) *Credentials {
+	return NewStatic(id, secret, token, SignatureV4A)
+}
+
 // NewStatic returns a pointer to a new Credentials object
 // wrapping a static credentials value provider.
 func NewStatic(id, secret, token string, signerType SignatureType) *Credentials {
@@ -70,3 +76,44 @@ func (s *Static) RetrieveWithCredContext(_ *CredContext) (Value, error) {
 func (s *Static) IsExpired() bool {
 	return false
 }
+
+// A StaticAccessKey is an access key set programmatically whose secret
+// key is held by an external signer, see signer.Signer. It will never
+// expire.
+type StaticAccessKey struct {
+	AccessKeyID  string
+	SessionToken string
+}
+
+// NewStaticAccessKey returns a pointer to a new Credentials object
+// wrapping a static access key without its secret key, for clients
+// signing requests with an external signer. If the access key is not
+// specified Value will return as anonymous.
+func NewStaticAccessKey(id, token string) *Credentials {
+	return New(&StaticAccessKey{AccessKeyID: id, SessionToken: token})
+}
+
+// Retrieve returns the access key with an empty secret key.
+func (s *StaticAccessKey) Retrieve() (Value, error) {
+	if s.AccessKeyID == "" {
+		// Anonymous is not an error
+		return Value{SignerType: SignatureAnonymous}, nil
+	}
+	return Value{
+		AccessKeyID:  s.AccessKeyID,
+		SessionToken: s.SessionToken,
+		SignerType:   SignatureV4,
+	}, nil
+}
+
+// RetrieveWithCredContext returns the access key with an empty secret key.
+func (s *StaticAccessKey) RetrieveWithCredContext(_ *CredContext) (Value, error) {
+	return s.Retrieve()
+}
+
+// IsExpired returns if the credentials are expired.
+//
+// For StaticAccessKey, the credentials never expired.
+func (s *StaticAccessKey) IsExpired() bool {
+	return false
+}
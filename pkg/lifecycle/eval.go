@@ -0,0 +1,316 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Action is a lifecycle action applying to an object version.
+type Action int
+
+const (
+	// NoneAction means no action applies.
+	NoneAction Action = iota
+	// DeleteAction expires the current version of an object, which
+	// adds a delete marker in a versioned bucket.
+	DeleteAction
+	// DeleteVersionAction permanently deletes a version, such as a
+	// noncurrent version or an expired object delete marker.
+	DeleteVersionAction
+	// DeleteAllVersionsAction deletes all versions of an object.
+	DeleteAllVersionsAction
+	// DelMarkerDeleteAllVersionsAction deletes all versions of an object
+	// whose current version is a delete marker.
+	DelMarkerDeleteAllVersionsAction
+	// TransitionAction transitions the current version to a storage class.
+	TransitionAction
+	// TransitionVersionAction transitions a noncurrent version to a
+	// storage class.
+	TransitionVersionAction
+	// AbortMultipartUploadAction aborts an incomplete multipart upload.
+	AbortMultipartUploadAction
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case DeleteAction:
+		return "Delete"
+	case DeleteVersionAction:
+		return "DeleteVersion"
+	case DeleteAllVersionsAction:
+		return "DeleteAllVersions"
+	case DelMarkerDeleteAllVersionsAction:
+		return "DelMarkerDeleteAllVersions"
+	case TransitionAction:
+		return "Transition"
+	case TransitionVersionAction:
+		return "TransitionVersion"
+	case AbortMultipartUploadAction:
+		return "AbortMultipartUpload"
+	default:
+		return "None"
+	}
+}
+
+// IsDelete returns true if the action deletes data.
+func (a Action) IsDelete() bool {
+	switch a {
+	case DeleteAction, DeleteVersionAction, DeleteAllVersionsAction, DelMarkerDeleteAllVersionsAction:
+		return true
+	}
+	return false
+}
+
+// IsTransition returns true if the action transitions data.
+func (a Action) IsTransition() bool {
+	return a == TransitionAction || a == TransitionVersionAction
+}
+
+// ObjectAttributes are the attributes of an object version, delete marker
+// or incomplete multipart upload evaluated against lifecycle rules.
+type ObjectAttributes struct {
+	// Key is the object name.
+	Key string
+	// Tags are the object tags.
+	Tags map[string]string
+	// Size of the object in bytes.
+	Size int64
+	// ModTime is the last modified time of the version, or the initiation
+	// time of an incomplete multipart upload.
+	ModTime time.Time
+
+	// IsLatest is true for the current version, and for objects of
+	// unversioned buckets.
+	IsLatest bool
+	// DeleteMarker is true for delete markers.
+	DeleteMarker bool
+	// NumVersions is the number of versions of the object including
+	// delete markers, a current delete marker with no other versions is
+	// an expired object delete marker.
+	NumVersions int
+	// NoncurrentSince is the time a noncurrent version became
+	// noncurrent, the last modified time of the next newer version.
+	NoncurrentSince time.Time
+	// NewerNoncurrentVersions is the number of noncurrent versions newer
+	// than a noncurrent version.
+	NewerNoncurrentVersions int
+	// Transitioned is true if the version was transitioned already.
+	Transitioned bool
+
+	// IncompleteUpload is true for incomplete multipart uploads.
+	IncompleteUpload bool
+}
+
+// Event is the lifecycle action applying to an object.
+type Event struct {
+	Action Action
+	// RuleID is the ID of the rule the action is from.
+	RuleID string
+	// Due is the time the action applies from.
+	Due time.Time
+	// StorageClass of transition actions.
+	StorageClass string
+}
+
+// ExpectedExpiryTime returns the time days after modTime, rounded up to
+// the next midnight UTC, as lifecycle actions configured in days apply.
+func ExpectedExpiryTime(modTime time.Time, days int) time.Time {
+	if days == 0 {
+		return modTime
+	}
+	t := modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour)
+	return t.Truncate(24 * time.Hour)
+}
+
+// FilterPrefix returns the key prefix of the objects the rule applies to.
+func (r Rule) FilterPrefix() string {
+	switch {
+	case r.RuleFilter.Prefix != "":
+		return r.RuleFilter.Prefix
+	case r.RuleFilter.And.Prefix != "":
+		return r.RuleFilter.And.Prefix
+	}
+	return r.Prefix
+}
+
+// Matches returns true if the rule is enabled and its filter selects
+// the object, by key prefix, tags and size. Size filters do not apply to
+// delete markers, and tag and size filters do not apply to incomplete
+// multipart uploads.
+func (r Rule) Matches(obj ObjectAttributes) bool {
+	if r.Status != "Enabled" || !strings.HasPrefix(obj.Key, r.FilterPrefix()) {
+		return false
+	}
+	if obj.IncompleteUpload {
+		return true
+	}
+
+	tags := r.RuleFilter.And.Tags
+	if !r.RuleFilter.Tag.IsEmpty() {
+		tags = []Tag{r.RuleFilter.Tag}
+	}
+	for _, tag := range tags {
+		if v, ok := obj.Tags[tag.Key]; !ok || v != tag.Value {
+			return false
+		}
+	}
+
+	if obj.DeleteMarker {
+		return true
+	}
+	for _, lt := range []int64{r.RuleFilter.ObjectSizeLessThan, r.RuleFilter.And.ObjectSizeLessThan} {
+		if lt > 0 && obj.Size >= lt {
+			return false
+		}
+	}
+	for _, gt := range []int64{r.RuleFilter.ObjectSizeGreaterThan, r.RuleFilter.And.ObjectSizeGreaterThan} {
+		if gt > 0 && obj.Size <= gt {
+			return false
+		}
+	}
+	return true
+}
+
+// Eval returns the lifecycle action applying to the object at now, with the
+// precedence of the MinIO server: of all actions due at now from all
+// matching rules, expiration is preferred over transition and otherwise
+// the action due first is returned. NoneAction is returned if no action is
+// due yet.
+func (c *Configuration) Eval(obj ObjectAttributes, now time.Time) Event {
+	if c.Empty() || obj.ModTime.IsZero() {
+		return Event{Action: NoneAction}
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	var events []Event
+	due := func(e Event) {
+		if now.After(e.Due) {
+			events = append(events, e)
+		}
+	}
+	for _, rule := range c.Rules {
+		if !rule.Matches(obj) {
+			continue
+		}
+
+		if obj.IncompleteUpload {
+			if !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+				due(Event{
+					Action: AbortMultipartUploadAction,
+					RuleID: rule.ID,
+					Due:    ExpectedExpiryTime(obj.ModTime, int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)),
+				})
+			}
+			continue
+		}
+
+		// A delete marker without other versions is removed either with
+		// ExpiredObjectDeleteMarker, or when it is as old as Days.
+		if obj.IsLatest && obj.DeleteMarker && obj.NumVersions == 1 {
+			if rule.Expiration.IsDeleteMarkerExpirationEnabled() {
+				events = append(events, Event{Action: DeleteVersionAction, RuleID: rule.ID, Due: now})
+				break
+			}
+			if !rule.Expiration.IsDaysNull() {
+				if expiry := ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days)); now.After(expiry) {
+					events = append(events, Event{Action: DeleteVersionAction, RuleID: rule.ID, Due: expiry})
+					break
+				}
+			}
+		}
+
+		if obj.IsLatest && !rule.AllVersionsExpiration.IsNull() && (!obj.DeleteMarker || rule.AllVersionsExpiration.DeleteMarker.IsEnabled()) {
+			due(Event{
+				Action: DeleteAllVersionsAction,
+				RuleID: rule.ID,
+				Due:    ExpectedExpiryTime(obj.ModTime, rule.AllVersionsExpiration.Days),
+			})
+		}
+
+		// No other action of the rule applies to a current delete marker.
+		if obj.IsLatest && obj.DeleteMarker {
+			if !rule.DelMarkerExpiration.IsNull() {
+				due(Event{
+					Action: DelMarkerDeleteAllVersionsAction,
+					RuleID: rule.ID,
+					Due:    ExpectedExpiryTime(obj.ModTime, rule.DelMarkerExpiration.Days),
+				})
+			}
+			continue
+		}
+
+		if !obj.IsLatest {
+			if nve := rule.NoncurrentVersionExpiration; !nve.isNull() && obj.NewerNoncurrentVersions >= nve.NewerNoncurrentVersions {
+				due(Event{
+					Action: DeleteVersionAction,
+					RuleID: rule.ID,
+					Due:    ExpectedExpiryTime(obj.NoncurrentSince, int(nve.NoncurrentDays)),
+				})
+			}
+			if nvt := rule.NoncurrentVersionTransition; !nvt.isNull() && !obj.DeleteMarker && !obj.Transitioned &&
+				obj.NewerNoncurrentVersions >= nvt.NewerNoncurrentVersions {
+				due(Event{
+					Action:       TransitionVersionAction,
+					RuleID:       rule.ID,
+					Due:          ExpectedExpiryTime(obj.NoncurrentSince, int(nvt.NoncurrentDays)),
+					StorageClass: nvt.StorageClass,
+				})
+			}
+			continue
+		}
+
+		switch {
+		case !rule.Expiration.IsDateNull():
+			due(Event{Action: DeleteAction, RuleID: rule.ID, Due: rule.Expiration.Date.Time})
+		case !rule.Expiration.IsDaysNull():
+			e := Event{Action: DeleteAction, RuleID: rule.ID, Due: ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))}
+			if rule.Expiration.DeleteAll.IsEnabled() {
+				e.Action = DeleteAllVersionsAction
+			}
+			due(e)
+		}
+
+		if t := rule.Transition; !t.IsNull() && !obj.Transitioned {
+			e := Event{Action: TransitionAction, RuleID: rule.ID, StorageClass: t.StorageClass}
+			if !t.IsDateNull() {
+				e.Due = t.Date.Time
+			} else {
+				e.Due = ExpectedExpiryTime(obj.ModTime, int(t.Days))
+			}
+			due(e)
+		}
+	}
+
+	if len(events) == 0 {
+		return Event{Action: NoneAction}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Action.IsDelete() != b.Action.IsDelete() {
+			return a.Action.IsDelete()
+		}
+		return a.Due.Before(b.Due)
+	})
+	return events[0]
+}
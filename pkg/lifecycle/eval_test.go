@@ -0,0 +1,160 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpectedExpiryTime(t *testing.T) {
+	modTime := time.Date(2026, 3, 1, 13, 30, 0, 0, time.UTC)
+	if got, want := ExpectedExpiryTime(modTime, 1), time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := ExpectedExpiryTime(modTime, 0); !got.Equal(modTime) {
+		t.Fatalf("expected %v, got %v", modTime, got)
+	}
+}
+
+func TestConfigurationEval(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := Configuration{Rules: []Rule{
+		{
+			ID:         "logs",
+			Status:     "Enabled",
+			RuleFilter: Filter{Prefix: "logs/"},
+			Expiration: Expiration{Days: 30},
+			Transition: Transition{Days: 7, StorageClass: "WARM"},
+		},
+		{
+			ID:     "tmp",
+			Status: "Enabled",
+			RuleFilter: Filter{And: And{
+				Prefix:                "tmp/",
+				Tags:                  []Tag{{Key: "temporary", Value: "true"}},
+				ObjectSizeGreaterThan: 1024,
+			}},
+			Expiration: Expiration{Days: 1},
+		},
+		{
+			ID:                             "versions",
+			Status:                         "Enabled",
+			NoncurrentVersionExpiration:    NoncurrentVersionExpiration{NoncurrentDays: 10, NewerNoncurrentVersions: 2},
+			NoncurrentVersionTransition:    NoncurrentVersionTransition{NoncurrentDays: 5, StorageClass: "COLD"},
+			Expiration:                     Expiration{DeleteMarker: true},
+			AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 3},
+		},
+		{
+			ID:                  "disabled",
+			Status:              "Disabled",
+			RuleFilter:          Filter{Prefix: "logs/"},
+			Expiration:          Expiration{Days: 1},
+			DelMarkerExpiration: DelMarkerExpiration{Days: 1},
+		},
+		{
+			ID:                  "markers",
+			Status:              "Enabled",
+			RuleFilter:          Filter{Prefix: "markers/"},
+			DelMarkerExpiration: DelMarkerExpiration{Days: 5},
+		},
+	}}
+
+	testCases := []struct {
+		name     string
+		obj      ObjectAttributes
+		expected Event
+	}{
+		{
+			name:     "transition",
+			obj:      ObjectAttributes{Key: "logs/a", ModTime: now.Add(-10 * day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: TransitionAction, RuleID: "logs", StorageClass: "WARM", Due: time.Date(2026, 5, 30, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "transitioned",
+			obj:      ObjectAttributes{Key: "logs/a", ModTime: now.Add(-10 * day), IsLatest: true, NumVersions: 1, Transitioned: true},
+			expected: Event{Action: NoneAction},
+		},
+		{
+			name:     "expiration over transition",
+			obj:      ObjectAttributes{Key: "logs/a", ModTime: now.Add(-40 * day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: DeleteAction, RuleID: "logs", Due: time.Date(2026, 5, 23, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "not due",
+			obj:      ObjectAttributes{Key: "logs/a", ModTime: now.Add(-day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: NoneAction},
+		},
+		{
+			name:     "tags and size",
+			obj:      ObjectAttributes{Key: "tmp/a", Tags: map[string]string{"temporary": "true"}, Size: 2048, ModTime: now.Add(-3 * day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: DeleteAction, RuleID: "tmp", Due: time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "missing tag",
+			obj:      ObjectAttributes{Key: "tmp/a", Size: 2048, ModTime: now.Add(-3 * day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: NoneAction},
+		},
+		{
+			name:     "too small",
+			obj:      ObjectAttributes{Key: "tmp/a", Tags: map[string]string{"temporary": "true"}, Size: 1024, ModTime: now.Add(-3 * day), IsLatest: true, NumVersions: 1},
+			expected: Event{Action: NoneAction},
+		},
+		{
+			name:     "noncurrent transition",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-30 * day), NoncurrentSince: now.Add(-6 * day), NumVersions: 3},
+			expected: Event{Action: TransitionVersionAction, RuleID: "versions", StorageClass: "COLD", Due: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "noncurrent expiration",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-30 * day), NoncurrentSince: now.Add(-20 * day), NumVersions: 4, NewerNoncurrentVersions: 2},
+			expected: Event{Action: DeleteVersionAction, RuleID: "versions", Due: time.Date(2026, 5, 23, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "newer noncurrent versions retained",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-30 * day), NoncurrentSince: now.Add(-20 * day), NumVersions: 4, NewerNoncurrentVersions: 1},
+			expected: Event{Action: TransitionVersionAction, RuleID: "versions", StorageClass: "COLD", Due: time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "expired object delete marker",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-day), IsLatest: true, DeleteMarker: true, NumVersions: 1},
+			expected: Event{Action: DeleteVersionAction, RuleID: "versions", Due: now},
+		},
+		{
+			name:     "delete marker with versions",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-day), IsLatest: true, DeleteMarker: true, NumVersions: 2},
+			expected: Event{Action: NoneAction},
+		},
+		{
+			name:     "delete marker expiration",
+			obj:      ObjectAttributes{Key: "markers/a", ModTime: now.Add(-10 * day), IsLatest: true, DeleteMarker: true, NumVersions: 3},
+			expected: Event{Action: DelMarkerDeleteAllVersionsAction, RuleID: "markers", Due: time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "abort incomplete upload",
+			obj:      ObjectAttributes{Key: "data/a", ModTime: now.Add(-4 * day), IncompleteUpload: true},
+			expected: Event{Action: AbortMultipartUploadAction, RuleID: "versions", Due: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, testCase := range testCases {
+		if event := cfg.Eval(testCase.obj, now); event != testCase.expected {
+			t.Errorf("%s: expected %+v, got %+v", testCase.name, testCase.expected, event)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Condition operators of policy statements. Operators may be suffixed with
+// "IfExists" to match requests without the condition key, and prefixed with
+// "ForAnyValue:" or "ForAllValues:" to compare multivalued keys.
+const (
+	StringEquals              = "StringEquals"
+	StringNotEquals           = "StringNotEquals"
+	StringEqualsIgnoreCase    = "StringEqualsIgnoreCase"
+	StringNotEqualsIgnoreCase = "StringNotEqualsIgnoreCase"
+	StringLike                = "StringLike"
+	StringNotLike             = "StringNotLike"
+	NumericEquals             = "NumericEquals"
+	NumericNotEquals          = "NumericNotEquals"
+	NumericLessThan           = "NumericLessThan"
+	NumericLessThanEquals     = "NumericLessThanEquals"
+	NumericGreaterThan        = "NumericGreaterThan"
+	NumericGreaterThanEquals  = "NumericGreaterThanEquals"
+	DateEquals                = "DateEquals"
+	DateNotEquals             = "DateNotEquals"
+	DateLessThan              = "DateLessThan"
+	DateLessThanEquals        = "DateLessThanEquals"
+	DateGreaterThan           = "DateGreaterThan"
+	DateGreaterThanEquals     = "DateGreaterThanEquals"
+	Bool                      = "Bool"
+	BinaryEquals              = "BinaryEquals"
+	IPAddress                 = "IpAddress"
+	NotIPAddress              = "NotIpAddress"
+	ArnEquals                 = "ArnEquals"
+	ArnNotEquals              = "ArnNotEquals"
+	ArnLike                   = "ArnLike"
+	ArnNotLike                = "ArnNotLike"
+	Null                      = "Null"
+)
+
+// Qualifiers of condition operators for multivalued condition keys.
+const (
+	ForAnyValue  = "ForAnyValue:"
+	ForAllValues = "ForAllValues:"
+)
+
+// conditionOperator - evaluates a condition operator.
+type conditionOperator struct {
+	// match returns whether the request value matches the condition
+	// value, negated operators match if it does not match any.
+	match   func(conditionValue, value string) bool
+	negated bool
+
+	// variables indicates that policy variables are substituted in the
+	// condition values.
+	variables bool
+}
+
+var conditionOperators = map[string]conditionOperator{
+	StringEquals:              {match: stringEquals, variables: true},
+	StringNotEquals:           {match: stringEquals, negated: true, variables: true},
+	StringEqualsIgnoreCase:    {match: strings.EqualFold, variables: true},
+	StringNotEqualsIgnoreCase: {match: strings.EqualFold, negated: true, variables: true},
+	StringLike:                {match: stringLike, variables: true},
+	StringNotLike:             {match: stringLike, negated: true, variables: true},
+	NumericEquals:             {match: numericMatch(func(c int) bool { return c == 0 })},
+	NumericNotEquals:          {match: numericMatch(func(c int) bool { return c == 0 }), negated: true},
+	NumericLessThan:           {match: numericMatch(func(c int) bool { return c < 0 })},
+	NumericLessThanEquals:     {match: numericMatch(func(c int) bool { return c <= 0 })},
+	NumericGreaterThan:        {match: numericMatch(func(c int) bool { return c > 0 })},
+	NumericGreaterThanEquals:  {match: numericMatch(func(c int) bool { return c >= 0 })},
+	DateEquals:                {match: dateMatch(func(c int) bool { return c == 0 })},
+	DateNotEquals:             {match: dateMatch(func(c int) bool { return c == 0 }), negated: true},
+	DateLessThan:              {match: dateMatch(func(c int) bool { return c < 0 })},
+	DateLessThanEquals:        {match: dateMatch(func(c int) bool { return c <= 0 })},
+	DateGreaterThan:           {match: dateMatch(func(c int) bool { return c > 0 })},
+	DateGreaterThanEquals:     {match: dateMatch(func(c int) bool { return c >= 0 })},
+	Bool:                      {match: boolEquals},
+	BinaryEquals:              {match: stringEquals},
+	IPAddress:                 {match: ipMatch},
+	NotIPAddress:              {match: ipMatch, negated: true},
+	ArnEquals:                 {match: stringLike, variables: true},
+	ArnNotEquals:              {match: stringLike, negated: true, variables: true},
+	ArnLike:                   {match: stringLike, variables: true},
+	ArnNotLike:                {match: stringLike, negated: true, variables: true},
+}
+
+// parseConditionOperator - splits op into the base operator, its
+// qualifier and IfExists suffix.
+func parseConditionOperator(op string) (base, qualifier string, ifExists bool, err error) {
+	base = op
+	for _, q := range []string{ForAnyValue, ForAllValues} {
+		if strings.HasPrefix(base, q) {
+			base, qualifier = strings.TrimPrefix(base, q), q
+			break
+		}
+	}
+	if base != Null {
+		base, ifExists = strings.CutSuffix(base, "IfExists")
+	}
+	if _, ok := conditionOperators[base]; !ok && base != Null {
+		return "", "", false, fmt.Errorf("unsupported condition operator %q", op)
+	}
+	return base, qualifier, ifExists, nil
+}
+
+// evalConditions - returns whether all conditions match the request,
+// values are keyed by lower case condition keys.
+func evalConditions(conditions ConditionMap, values map[string][]string) bool {
+	for op, keys := range conditions {
+		for key, conditionValues := range keys {
+			if !evalCondition(op, conditionValues.ToSlice(), values[strings.ToLower(key)], values) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// evalCondition - returns whether the request values of a condition key
+// match the condition values of operator op.
+func evalCondition(op string, conditionValues, requestValues []string, values map[string][]string) bool {
+	base, qualifier, ifExists, err := parseConditionOperator(op)
+	if err != nil {
+		return false
+	}
+	if base == Null {
+		absent := len(requestValues) == 0
+		for _, cv := range conditionValues {
+			if b, err := strconv.ParseBool(cv); err == nil && b == absent {
+				return true
+			}
+		}
+		return false
+	}
+
+	operator := conditionOperators[base]
+	if len(requestValues) == 0 {
+		switch {
+		case ifExists, qualifier == ForAllValues:
+			return true
+		case qualifier == ForAnyValue:
+			return false
+		}
+		return operator.negated
+	}
+
+	if operator.variables {
+		substituted := make([]string, len(conditionValues))
+		for i, cv := range conditionValues {
+			substituted[i] = substituteVariables(cv, values)
+		}
+		conditionValues = substituted
+	}
+	matches := func(v string) bool {
+		for _, cv := range conditionValues {
+			if operator.match(cv, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch qualifier {
+	case ForAllValues:
+		for _, v := range requestValues {
+			if matches(v) == operator.negated {
+				return false
+			}
+		}
+		return true
+	case ForAnyValue:
+		for _, v := range requestValues {
+			if matches(v) != operator.negated {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range requestValues {
+		if matches(v) {
+			return !operator.negated
+		}
+	}
+	return operator.negated
+}
+
+func stringEquals(conditionValue, value string) bool {
+	return conditionValue == value
+}
+
+func stringLike(conditionValue, value string) bool {
+	return wildcardMatch(conditionValue, value, false)
+}
+
+func boolEquals(conditionValue, value string) bool {
+	c, err := strconv.ParseBool(conditionValue)
+	if err != nil {
+		return false
+	}
+	v, err := strconv.ParseBool(value)
+	return err == nil && c == v
+}
+
+// numericMatch - returns a matcher comparing the request value to the
+// condition value as numbers.
+func numericMatch(cmp func(int) bool) func(string, string) bool {
+	return func(conditionValue, value string) bool {
+		c, err := strconv.ParseFloat(conditionValue, 64)
+		if err != nil {
+			return false
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		switch {
+		case v < c:
+			return cmp(-1)
+		case v > c:
+			return cmp(1)
+		}
+		return cmp(0)
+	}
+}
+
+// dateMatch - returns a matcher comparing the request value to the
+// condition value as dates.
+func dateMatch(cmp func(int) bool) func(string, string) bool {
+	return func(conditionValue, value string) bool {
+		c, err := parseConditionDate(conditionValue)
+		if err != nil {
+			return false
+		}
+		v, err := parseConditionDate(value)
+		if err != nil {
+			return false
+		}
+		return cmp(v.Compare(c))
+	}
+}
+
+// parseConditionDate - parses an ISO 8601 date, or seconds since the
+// epoch.
+func parseConditionDate(s string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// ipMatch - returns whether the request IP address is in the condition
+// CIDR block or equal to the condition address.
+func ipMatch(conditionValue, value string) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+	if _, network, err := net.ParseCIDR(conditionValue); err == nil {
+		return network.Contains(ip)
+	}
+	c := net.ParseIP(conditionValue)
+	return c != nil && c.Equal(ip)
+}
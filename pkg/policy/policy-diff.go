@@ -0,0 +1,282 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Access - effective access of requests under a policy.
+type Access int
+
+// Kinds of effective access.
+const (
+	// AccessDenied - requests are denied.
+	AccessDenied Access = iota
+	// AccessConditional - requests are allowed depending on conditions.
+	AccessConditional
+	// AccessAllowed - requests are allowed.
+	AccessAllowed
+)
+
+// String - returns the name of the access.
+func (a Access) String() string {
+	switch a {
+	case AccessAllowed:
+		return "allowed"
+	case AccessConditional:
+		return "conditional"
+	default:
+		return "denied"
+	}
+}
+
+// Permission - effective permission of a principal to perform an action
+// on a resource.
+type Permission struct {
+	Access Access
+
+	// AllowConditions of conditional access are the conditions of the
+	// Allow statements, one of which must be met, and DenyConditions those
+	// of the Deny statements, none of which may be met.
+	AllowConditions []ConditionMap
+	DenyConditions  []ConditionMap
+}
+
+// String - returns a printable description of the permission.
+func (p Permission) String() string {
+	if p.Access != AccessConditional {
+		return p.Access.String()
+	}
+	s := p.Access.String()
+	if len(p.AllowConditions) > 0 {
+		data, _ := json.Marshal(p.AllowConditions)
+		s += " allow if any of " + string(data)
+	}
+	if len(p.DenyConditions) > 0 {
+		data, _ := json.Marshal(p.DenyConditions)
+		s += " deny if any of " + string(data)
+	}
+	return s
+}
+
+// equal - returns whether the permissions are the same.
+func (p Permission) equal(other Permission) bool {
+	if p.Access != other.Access {
+		return false
+	}
+	a, _ := json.Marshal(p)
+	b, _ := json.Marshal(other)
+	return string(a) == string(b)
+}
+
+// PermissionChange - change of the effective permission of a principal to
+// perform an action on a resource.
+type PermissionChange struct {
+	Principal string
+	Action    string
+	Resource  string
+	Old, New  Permission
+}
+
+// String - returns a printable description of the change.
+func (c PermissionChange) String() string {
+	return fmt.Sprintf("%s on %s for %s: %s -> %s", c.Action, c.Resource, c.Principal, c.Old, c.New)
+}
+
+// Diff - parses two policy documents and returns the changes of effective
+// permissions from oldPolicy to newPolicy, rather than textual changes.
+//
+// Permissions are compared for each principal, action and resource named
+// in either policy, wildcard actions are expanded to the known S3 actions
+// they match and actions are paired only with resources they apply to.
+// Wildcard actions matching no known S3 action are compared as written.
+// Permissions depending on conditions are reported as conditional with the
+// conditions of the statements, which are compared as written.
+func Diff(oldPolicy, newPolicy []byte) ([]PermissionChange, error) {
+	oldP, err := ParsePolicy(oldPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("old policy: %w", err)
+	}
+	newP, err := ParsePolicy(newPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("new policy: %w", err)
+	}
+
+	principals := make(map[string]string)
+	// Actions by lower case action.
+	actions := make(map[string]string)
+	resources := make(map[string]string)
+	for _, s := range append(append([]PolicyStatement{}, oldP.Statements...), newP.Statements...) {
+		for _, p := range []*Principal{s.Principal, s.NotPrincipal} {
+			if p == nil {
+				continue
+			}
+			for v := range p.values() {
+				principals[v] = sampleOf(v)
+			}
+		}
+		if len(s.NotActions) > 0 {
+			for action := range s3Actions {
+				actions[action] = actionName(action)
+			}
+		}
+		for a := range s.Actions {
+			if !strings.ContainsAny(a, "*?") {
+				actions[strings.ToLower(a)] = actionName(a)
+				continue
+			}
+			expanded := false
+			for action := range s3Actions {
+				if wildcardMatch(a, action, true) {
+					actions[action] = actionName(action)
+					expanded = true
+				}
+			}
+			if !expanded {
+				// Such as the actions of other services.
+				actions[strings.ToLower(a)] = a
+			}
+		}
+		for r := range s.Resources.Union(s.NotResources) {
+			resources[r] = sampleOf(r)
+		}
+	}
+	// Anonymous requests are always compared.
+	principals["*"] = "*"
+	if len(resources) == 0 {
+		resources["*"] = "*"
+	}
+
+	var changes []PermissionChange
+	for action, name := range actions {
+		kind, known := s3Actions[action]
+		for resource, resourceSample := range resources {
+			if kinds, ok := resourceKinds(resource); known && ok && kinds&kind == 0 {
+				continue
+			}
+			for principal, principalSample := range principals {
+				oldPerm := oldP.permission(principalSample, action, resourceSample)
+				newPerm := newP.permission(principalSample, action, resourceSample)
+				if !oldPerm.equal(newPerm) {
+					changes = append(changes, PermissionChange{
+						Principal: principal,
+						Action:    name,
+						Resource:  resource,
+						Old:       oldPerm,
+						New:       newPerm,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.Principal < b.Principal
+	})
+	return changes, nil
+}
+
+// permission - returns the effective permission of a request regardless
+// of the condition values of the request.
+func (p Policy) permission(principal, action, resource string) Permission {
+	var (
+		allowed                         bool
+		allowConditions, denyConditions []ConditionMap
+	)
+	for _, s := range p.Statements {
+		if !s.matches(principal, action, resource, nil) {
+			continue
+		}
+		switch {
+		case s.Effect == Deny && len(s.Conditions) == 0:
+			return Permission{Access: AccessDenied}
+		case s.Effect == Deny:
+			denyConditions = append(denyConditions, s.Conditions)
+		case len(s.Conditions) == 0:
+			allowed = true
+		default:
+			allowConditions = append(allowConditions, s.Conditions)
+		}
+	}
+	switch {
+	case !allowed && len(allowConditions) == 0:
+		return Permission{Access: AccessDenied}
+	case allowed && len(denyConditions) == 0:
+		return Permission{Access: AccessAllowed}
+	case allowed:
+		allowConditions = nil
+	}
+	return Permission{
+		Access:          AccessConditional,
+		AllowConditions: sortConditions(allowConditions),
+		DenyConditions:  sortConditions(denyConditions),
+	}
+}
+
+// sortConditions - returns the distinct conditions sorted by their JSON
+// form, so that permissions do not depend on the order of statements.
+func sortConditions(conditions []ConditionMap) []ConditionMap {
+	if len(conditions) == 0 {
+		return conditions
+	}
+	byJSON := make(map[string]ConditionMap, len(conditions))
+	keys := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		data, _ := json.Marshal(c)
+		if _, ok := byJSON[string(data)]; !ok {
+			byJSON[string(data)] = c
+			keys = append(keys, string(data))
+		}
+	}
+	sort.Strings(keys)
+	sorted := make([]ConditionMap, 0, len(keys))
+	for _, k := range keys {
+		sorted = append(sorted, byJSON[k])
+	}
+	return sorted
+}
+
+// sampleOf - returns a string matched by the wildcard pattern.
+func sampleOf(pattern string) string {
+	if pattern == "*" {
+		return pattern
+	}
+	return strings.NewReplacer("*", "x", "?", "x").Replace(pattern)
+}
+
+// actionName - returns the S3 action with its usual case.
+func actionName(action string) string {
+	for _, names := range s3ActionKinds {
+		for _, name := range names {
+			if strings.EqualFold(name, action) {
+				return name
+			}
+		}
+	}
+	return action
+}
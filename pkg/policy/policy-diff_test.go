@@ -0,0 +1,126 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*"},
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:ListBucket"], "Resource": "arn:aws:s3:::bucket"}
+  ]
+}`
+	newPolicy := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/public/*"},
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:ListBucket"], "Resource": "arn:aws:s3:::bucket",
+     "Condition": {"StringLike": {"s3:prefix": "home/alice/*"}}},
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:Put*"], "Resource": "arn:aws:s3:::bucket/home/alice/*"}
+  ]
+}`
+	changes, err := Diff([]byte(oldPolicy), []byte(newPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`s3:ListBucket on arn:aws:s3:::bucket for arn:aws:iam::123456789012:user/alice: allowed -> conditional allow if any of [{"StringLike":{"s3:prefix":["home/alice/*"]}}]`,
+		`s3:GetObject on arn:aws:s3:::bucket/* for *: allowed -> denied`,
+		`s3:GetObject on arn:aws:s3:::bucket/* for arn:aws:iam::123456789012:user/alice: allowed -> denied`,
+		`s3:GetObject on arn:aws:s3:::bucket/home/alice/* for *: allowed -> denied`,
+		`s3:GetObject on arn:aws:s3:::bucket/home/alice/* for arn:aws:iam::123456789012:user/alice: allowed -> denied`,
+		`s3:PutObject on arn:aws:s3:::bucket/home/alice/* for arn:aws:iam::123456789012:user/alice: denied -> allowed`,
+	}
+	var got []string
+	for _, c := range changes {
+		if c.Action == "s3:PutObject" || c.Action == "s3:GetObject" || c.Action == "s3:ListBucket" {
+			got = append(got, c.String())
+		}
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d changes, got %d: %q", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("change %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+	for _, c := range changes {
+		if c.Old.Access == AccessAllowed || c.New.Access != AccessAllowed {
+			continue
+		}
+		if c.Principal != "arn:aws:iam::123456789012:user/alice" || c.Resource != "arn:aws:s3:::bucket/home/alice/*" {
+			t.Errorf("unexpected grant %s", c)
+		}
+	}
+
+	if changes, err = Diff([]byte(oldPolicy), []byte(oldPolicy)); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v, %v", changes, err)
+	}
+
+	// Reordering statements changes no permission.
+	first := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"StringEquals": {"aws:Referer": "https://example.com"}}},
+    {"Effect": "Deny", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"Bool": {"aws:SecureTransport": "false"}}}
+  ]
+}`
+	reordered := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Deny", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"Bool": {"aws:SecureTransport": "false"}}},
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"StringEquals": {"aws:Referer": "https://example.com"}}},
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}
+  ]
+}`
+	if changes, err = Diff([]byte(first), []byte(reordered)); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes after reordering statements, got %v, %v", changes, err)
+	}
+
+	// Wildcards matching no known action are compared as written.
+	other := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject", "s3:FutureAction*"], "Resource": "arn:aws:s3:::bucket/*"}
+  ]
+}`
+	if changes, err = Diff([]byte(first), []byte(other)); err != nil {
+		t.Fatal(err)
+	}
+	var reported bool
+	for _, c := range changes {
+		if c.Action == "s3:FutureAction*" {
+			reported = c.Old.Access == AccessDenied && c.New.Access == AccessAllowed
+		}
+	}
+	if !reported {
+		t.Fatalf("expected s3:FutureAction* to be reported, got %v", changes)
+	}
+}
@@ -0,0 +1,424 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// LintRule - rule of the policy linter.
+type LintRule string
+
+// Rules of the policy linter.
+const (
+	// LintUnreachable - an Allow statement is overridden by an
+	// unconditional Deny statement.
+	LintUnreachable LintRule = "unreachable-statement"
+	// LintRedundant - a statement is covered by another unconditional
+	// statement of the same effect.
+	LintRedundant LintRule = "redundant-statement"
+	// LintBroadWildcard - an Allow statement grants all actions or all
+	// resources.
+	LintBroadWildcard LintRule = "broad-wildcard"
+	// LintPublicPrincipal - an Allow statement grants access to everyone
+	// without restricting conditions.
+	LintPublicPrincipal LintRule = "public-principal"
+	// LintInsecureTransport - the policy does not deny requests without
+	// TLS with the aws:SecureTransport condition key.
+	LintInsecureTransport LintRule = "insecure-transport"
+	// LintInvalidAction - an action does not apply to any resource of its
+	// statement, such as s3:GetObject on a bucket.
+	LintInvalidAction LintRule = "invalid-action-resource"
+	// LintUnknownAction - an S3 action is neither an AWS nor a MinIO
+	// action.
+	LintUnknownAction LintRule = "unknown-action"
+)
+
+// LintIssue - issue found by the policy linter.
+type LintIssue struct {
+	Rule LintRule
+	// Statement is the index of the statement, or -1 for issues of the
+	// whole policy.
+	Statement int
+	Sid       string
+	Message   string
+}
+
+// String - returns a printable description of the issue.
+func (i LintIssue) String() string {
+	switch {
+	case i.Statement < 0:
+		return fmt.Sprintf("%s: %s", i.Rule, i.Message)
+	case i.Sid != "":
+		return fmt.Sprintf("%s: statement %q: %s", i.Rule, i.Sid, i.Message)
+	}
+	return fmt.Sprintf("%s: statement %d: %s", i.Rule, i.Statement, i.Message)
+}
+
+// Kinds of resources S3 actions apply to, as bits.
+const (
+	serviceAction = 1 << iota
+	bucketAction
+	objectAction
+	// Access points, batch jobs, Storage Lens, multi-region access points
+	// and access grants.
+	otherAction
+
+	anyAction = serviceAction | bucketAction | objectAction | otherAction
+)
+
+// s3ActionKinds - S3 actions of AWS and MinIO by the kind of resource they
+// apply to.
+var s3ActionKinds = map[int][]string{
+	serviceAction: {
+		"s3:ListAllMyBuckets", "s3:ListenNotification",
+		"s3:GetAccountPublicAccessBlock", "s3:PutAccountPublicAccessBlock",
+		"s3:ListAccessPoints", "s3:ListAccessPointsForObjectLambda", "s3:ListMultiRegionAccessPoints",
+		"s3:ListJobs", "s3:CreateJob",
+		"s3:ListStorageLensConfigurations", "s3:ListStorageLensGroups", "s3:CreateStorageLensGroup",
+		"s3:ListAccessGrantsInstances", "s3:ListCallerAccessGrants",
+		"s3:PutAccessPointPublicAccessBlock", "s3:GetAccessPoint",
+	},
+	bucketAction: {
+		"s3:CreateBucket", "s3:DeleteBucket", "s3:ForceDeleteBucket",
+		"s3:ListBucket", "s3:ListBucketVersions", "s3:ListBucketMultipartUploads",
+		"s3:GetBucketLocation", "s3:ListenBucketNotification",
+		"s3:GetBucketPolicy", "s3:PutBucketPolicy", "s3:DeleteBucketPolicy", "s3:GetBucketPolicyStatus",
+		"s3:GetBucketVersioning", "s3:PutBucketVersioning",
+		"s3:GetBucketTagging", "s3:PutBucketTagging",
+		"s3:GetBucketNotification", "s3:PutBucketNotification",
+		"s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration",
+		"s3:GetReplicationConfiguration", "s3:PutReplicationConfiguration", "s3:ResetBucketReplicationState",
+		"s3:GetBucketObjectLockConfiguration", "s3:PutBucketObjectLockConfiguration",
+		"s3:GetEncryptionConfiguration", "s3:PutEncryptionConfiguration",
+		"s3:GetBucketCORS", "s3:PutBucketCORS",
+		"s3:GetBucketAcl", "s3:PutBucketAcl",
+		"s3:GetBucketPublicAccessBlock", "s3:PutBucketPublicAccessBlock",
+		"s3:GetBucketOwnershipControls", "s3:PutBucketOwnershipControls", "s3:DeleteBucketOwnershipControls",
+		"s3:GetBucketWebsite", "s3:PutBucketWebsite", "s3:DeleteBucketWebsite",
+		"s3:GetBucketLogging", "s3:PutBucketLogging",
+		"s3:GetAccelerateConfiguration", "s3:PutAccelerateConfiguration",
+		"s3:GetBucketRequestPayment", "s3:PutBucketRequestPayment",
+		"s3:GetInventoryConfiguration", "s3:PutInventoryConfiguration",
+		"s3:GetAnalyticsConfiguration", "s3:PutAnalyticsConfiguration",
+		"s3:GetMetricsConfiguration", "s3:PutMetricsConfiguration",
+		"s3:GetIntelligentTieringConfiguration", "s3:PutIntelligentTieringConfiguration",
+		"s3:GetBucketMetadataTableConfiguration", "s3:CreateBucketMetadataTableConfiguration",
+		"s3:DeleteBucketMetadataTableConfiguration",
+	},
+	objectAction: {
+		"s3:GetObject", "s3:GetObjectVersion", "s3:GetObjectAttributes", "s3:GetObjectVersionAttributes",
+		"s3:GetObjectTorrent", "s3:GetObjectVersionTorrent",
+		"s3:PutObject", "s3:PutObjectFanOut", "s3:DeleteObject", "s3:DeleteObjectVersion", "s3:RestoreObject",
+		"s3:AbortMultipartUpload", "s3:ListMultipartUploadParts",
+		"s3:GetObjectAcl", "s3:GetObjectVersionAcl", "s3:PutObjectAcl", "s3:PutObjectVersionAcl",
+		"s3:ObjectOwnerOverrideToBucketOwner",
+		"s3:GetObjectTagging", "s3:GetObjectVersionTagging", "s3:PutObjectTagging", "s3:PutObjectVersionTagging",
+		"s3:DeleteObjectTagging", "s3:DeleteObjectVersionTagging",
+		"s3:GetObjectLegalHold", "s3:PutObjectLegalHold",
+		"s3:GetObjectRetention", "s3:PutObjectRetention", "s3:BypassGovernanceRetention",
+		"s3:ReplicateObject", "s3:ReplicateDelete", "s3:ReplicateTags", "s3:GetObjectVersionForReplication",
+		"s3:InitiateReplication",
+	},
+	otherAction: {
+		"s3:CreateAccessPoint", "s3:DeleteAccessPoint",
+		"s3:GetAccessPointPolicy", "s3:PutAccessPointPolicy", "s3:DeleteAccessPointPolicy", "s3:GetAccessPointPolicyStatus",
+		"s3:CreateAccessPointForObjectLambda", "s3:DeleteAccessPointForObjectLambda", "s3:GetAccessPointForObjectLambda",
+		"s3:GetAccessPointConfigurationForObjectLambda", "s3:PutAccessPointConfigurationForObjectLambda",
+		"s3:GetAccessPointPolicyForObjectLambda", "s3:PutAccessPointPolicyForObjectLambda",
+		"s3:DeleteAccessPointPolicyForObjectLambda", "s3:GetAccessPointPolicyStatusForObjectLambda",
+		"s3:DescribeJob", "s3:UpdateJobPriority", "s3:UpdateJobStatus",
+		"s3:GetJobTagging", "s3:PutJobTagging", "s3:DeleteJobTagging",
+		"s3:GetStorageLensConfiguration", "s3:PutStorageLensConfiguration", "s3:DeleteStorageLensConfiguration",
+		"s3:GetStorageLensConfigurationTagging", "s3:PutStorageLensConfigurationTagging",
+		"s3:DeleteStorageLensConfigurationTagging", "s3:GetStorageLensDashboard",
+		"s3:GetStorageLensGroup", "s3:UpdateStorageLensGroup", "s3:DeleteStorageLensGroup",
+		"s3:CreateMultiRegionAccessPoint", "s3:DeleteMultiRegionAccessPoint", "s3:GetMultiRegionAccessPoint",
+		"s3:DescribeMultiRegionAccessPointOperation", "s3:GetMultiRegionAccessPointPolicy",
+		"s3:PutMultiRegionAccessPointPolicy", "s3:GetMultiRegionAccessPointPolicyStatus",
+		"s3:GetMultiRegionAccessPointRoutes", "s3:SubmitMultiRegionAccessPointRoutes",
+		"s3:CreateAccessGrantsInstance", "s3:DeleteAccessGrantsInstance", "s3:GetAccessGrantsInstance",
+		"s3:GetAccessGrantsInstanceForPrefix", "s3:GetAccessGrantsInstanceResourcePolicy",
+		"s3:PutAccessGrantsInstanceResourcePolicy", "s3:DeleteAccessGrantsInstanceResourcePolicy",
+		"s3:AssociateAccessGrantsIdentityCenter", "s3:DissociateAccessGrantsIdentityCenter",
+		"s3:CreateAccessGrant", "s3:DeleteAccessGrant", "s3:GetAccessGrant", "s3:ListAccessGrants",
+		"s3:CreateAccessGrantsLocation", "s3:DeleteAccessGrantsLocation", "s3:GetAccessGrantsLocation",
+		"s3:UpdateAccessGrantsLocation", "s3:ListAccessGrantsLocations", "s3:GetDataAccess",
+	},
+	anyAction: {
+		"s3:ListTagsForResource", "s3:TagResource", "s3:UntagResource",
+	},
+}
+
+// s3Actions - kinds of resources of S3 actions, keyed by lower case
+// action.
+var s3Actions = func() map[string]int {
+	actions := make(map[string]int)
+	for kind, names := range s3ActionKinds {
+		for _, name := range names {
+			actions[strings.ToLower(name)] = kind
+		}
+	}
+	return actions
+}()
+
+// resourceKinds - returns the kinds of actions an S3 resource applies to,
+// ok is false for resources of other services.
+func resourceKinds(resource string) (kinds int, ok bool) {
+	if resource == "*" {
+		return anyAction, true
+	}
+	fields := strings.SplitN(resource, ":", 6)
+	if len(fields) != 6 || fields[0] != "arn" || fields[2] != "s3" {
+		return 0, false
+	}
+	if fields[3] != "" || fields[4] != "" {
+		// Access points, jobs and other resources of a region or
+		// account rather than buckets and objects.
+		return 0, false
+	}
+	path := fields[5]
+	switch {
+	case path == "*":
+		return anyAction, true
+	case strings.Contains(path, "/"):
+		return objectAction, true
+	case strings.ContainsAny(path, "*?"):
+		// Wildcards may match the "/" of object keys.
+		return bucketAction | objectAction, true
+	}
+	return bucketAction, true
+}
+
+// restrictingConditionKeys - condition keys restricting who can use a
+// public statement.
+var restrictingConditionKeys = set.CreateStringSet(
+	"aws:sourceip", "aws:sourcevpc", "aws:sourcevpce", "aws:sourcearn",
+	"aws:sourceaccount", "aws:sourceorgid", "aws:principalorgid",
+	"aws:principalaccount", "aws:principalarn", "aws:userid", "aws:username",
+)
+
+// Lint - parses a policy document and returns issues which are likely
+// mistakes or weaken the security of the bucket: statements which never
+// take effect, grants of all actions or resources, grants to everyone,
+// missing denial of requests without TLS and actions which do not apply to
+// the resources of their statement.
+func Lint(policyJSON []byte) ([]LintIssue, error) {
+	p, err := ParsePolicy(policyJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []LintIssue
+	report := func(i int, rule LintRule, format string, args ...any) {
+		issue := LintIssue{Rule: rule, Statement: i, Message: fmt.Sprintf(format, args...)}
+		if i >= 0 {
+			issue.Sid = p.Statements[i].Sid
+		}
+		issues = append(issues, issue)
+	}
+
+	for i, s := range p.Statements {
+		if j := p.coveringStatement(i, Deny); s.Effect == Allow && j >= 0 {
+			report(i, LintUnreachable, "overridden by Deny statement %s", statementName(p.Statements[j], j))
+		} else if j = p.coveringStatement(i, s.Effect); j >= 0 {
+			report(i, LintRedundant, "covered by statement %s", statementName(p.Statements[j], j))
+		}
+
+		if s.Effect == Allow {
+			switch {
+			case len(s.NotActions) > 0:
+				report(i, LintBroadWildcard, "NotAction allows all other actions")
+			case s.Actions.Contains("*") || !s.Actions.FuncMatch(strings.EqualFold, "s3:*").IsEmpty():
+				report(i, LintBroadWildcard, "allows all actions")
+			}
+			switch {
+			case len(s.NotResources) > 0:
+				report(i, LintBroadWildcard, "NotResource allows all other resources")
+			case s.Resources.Contains("*") || s.Resources.Contains(awsResourcePrefix+"*") || s.Resources.Contains(awsResourcePrefix+"*/*"):
+				report(i, LintBroadWildcard, "allows all resources")
+			}
+			public := s.NotPrincipal != nil || (s.Principal != nil && s.Principal.IsWildcard())
+			if public && !hasRestrictingCondition(s.Conditions) {
+				report(i, LintPublicPrincipal, "allows access to everyone")
+			}
+		}
+
+		for action := range s.Actions {
+			lower := strings.ToLower(action)
+			if !strings.HasPrefix(lower, "s3:") || strings.ContainsAny(lower, "*?") {
+				continue
+			}
+			kind, ok := s3Actions[lower]
+			if !ok {
+				report(i, LintUnknownAction, "unknown action %s", action)
+				continue
+			}
+			if len(s.Resources) == 0 {
+				continue
+			}
+			applies := false
+			for resource := range s.Resources {
+				kinds, ok := resourceKinds(resource)
+				if !ok || kinds&kind != 0 {
+					applies = true
+					break
+				}
+			}
+			if !applies {
+				report(i, LintInvalidAction, "action %s does not apply to the resources %s", action, s.Resources)
+			}
+		}
+	}
+
+	if !requiresSecureTransport(p) {
+		report(-1, LintInsecureTransport, "requests without TLS are not denied with the aws:SecureTransport condition key")
+	}
+	return issues, nil
+}
+
+// coveringStatement - returns the index of an unconditional statement
+// with effect which applies to all requests the statement i applies to,
+// or -1. Of two statements covering each other only the latter one is
+// covered.
+func (p Policy) coveringStatement(i int, effect Effect) int {
+	s := p.Statements[i]
+	for j, other := range p.Statements {
+		if i == j || other.Effect != effect || len(other.Conditions) > 0 || !other.covers(s) {
+			continue
+		}
+		if s.Effect != effect || j < i || len(s.Conditions) > 0 || !s.covers(other) {
+			return j
+		}
+	}
+	return -1
+}
+
+// statementName - returns the Sid of a statement, or its index.
+func statementName(s PolicyStatement, i int) string {
+	if s.Sid != "" {
+		return strconv.Quote(s.Sid)
+	}
+	return strconv.Itoa(i)
+}
+
+// hasRestrictingCondition - returns whether conditions restrict the
+// source or identity of requests.
+func hasRestrictingCondition(conditions ConditionMap) bool {
+	for _, keys := range conditions {
+		for key := range keys {
+			if restrictingConditionKeys.Contains(strings.ToLower(key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresSecureTransport - returns whether the policy denies requests
+// without TLS, or allows only requests with TLS.
+func requiresSecureTransport(p Policy) bool {
+	allowsRequireTLS := true
+	for _, s := range p.Statements {
+		switch s.Effect {
+		case Deny:
+			if secureTransportCondition(s.Conditions, "false") {
+				return true
+			}
+		case Allow:
+			if !secureTransportCondition(s.Conditions, "true") {
+				allowsRequireTLS = false
+			}
+		}
+	}
+	return allowsRequireTLS
+}
+
+// secureTransportCondition - returns whether conditions test that
+// aws:SecureTransport is value.
+func secureTransportCondition(conditions ConditionMap, value string) bool {
+	for op, keys := range conditions {
+		if op != Bool && op != Bool+"IfExists" {
+			continue
+		}
+		for key, values := range keys {
+			if strings.EqualFold(key, "aws:SecureTransport") && values.Contains(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// covers - returns whether s applies to all requests other applies to,
+// regardless of conditions.
+func (s PolicyStatement) covers(other PolicyStatement) bool {
+	switch {
+	case s.NotPrincipal != nil:
+		return false
+	case s.Principal != nil && !s.Principal.IsWildcard():
+		if other.Principal == nil || !patternsCover(s.Principal.values(), other.Principal.values(), false) {
+			return false
+		}
+	}
+
+	if len(s.Actions) == 0 {
+		return false
+	}
+	otherActions := other.Actions
+	if len(other.NotActions) > 0 {
+		otherActions = set.CreateStringSet("*")
+	}
+	if !patternsCover(s.Actions, otherActions, true) {
+		return false
+	}
+
+	switch {
+	case len(s.NotResources) > 0:
+		return false
+	case len(s.Resources) == 0:
+		return true
+	}
+	otherResources := other.Resources
+	if len(otherResources) == 0 {
+		otherResources = set.CreateStringSet("*")
+	}
+	return patternsCover(s.Resources, otherResources, false)
+}
+
+// patternsCover - returns whether the wildcard patterns a match all
+// strings matched by the patterns b.
+func patternsCover(a, b set.StringSet, ignoreCase bool) bool {
+	for pb := range b {
+		covered := false
+		for pa := range a {
+			if wildcardMatch(pa, pb, ignoreCase) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	p := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Sid": "Public", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*"},
+    {"Sid": "Office", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*",
+     "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}},
+    {"Sid": "Admin", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/admin"}, "Action": "s3:*", "Resource": "*"},
+    {"Sid": "Secret", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/secret/*"},
+    {"Sid": "DenySecret", "Effect": "Deny", "Principal": "*", "Action": "s3:Get*", "Resource": "arn:aws:s3:::bucket/secret/*"},
+    {"Sid": "List", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:ListBucket", "s3:GetObjects"], "Resource": "arn:aws:s3:::bucket/*"}
+  ]
+}`
+	issues, err := Lint([]byte(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]bool{
+		"public-principal: statement \"Public\": allows access to everyone":                                                         true,
+		"redundant-statement: statement \"Office\": covered by statement \"Public\"":                                                true,
+		"broad-wildcard: statement \"Admin\": allows all actions":                                                                   true,
+		"broad-wildcard: statement \"Admin\": allows all resources":                                                                 true,
+		"unreachable-statement: statement \"Secret\": overridden by Deny statement \"DenySecret\"":                                  true,
+		"invalid-action-resource: statement \"List\": action s3:ListBucket does not apply to the resources [arn:aws:s3:::bucket/*]": true,
+		"unknown-action: statement \"List\": unknown action s3:GetObjects":                                                          true,
+		"insecure-transport: requests without TLS are not denied with the aws:SecureTransport condition key":                        true,
+	}
+	for _, issue := range issues {
+		if !expected[issue.String()] {
+			t.Errorf("unexpected issue %q", issue)
+		}
+		delete(expected, issue.String())
+	}
+	for issue := range expected {
+		t.Errorf("missing issue %q", issue)
+	}
+
+	p = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:GetObject", "s3:PutObject",
+     "s3:GetObjectTorrent", "s3:ObjectOwnerOverrideToBucketOwner", "s3:PutObjectFanOut"], "Resource": "arn:aws:s3:::bucket/*"},
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": "s3:DeleteBucketOwnershipControls", "Resource": "arn:aws:s3:::bucket"},
+    {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:user/alice"}, "Action": ["s3:GetAccessPointPolicy", "s3:GetObject"],
+     "Resource": ["arn:aws:s3:us-east-1:123456789012:accesspoint/ap", "arn:aws:s3:us-east-1:123456789012:accesspoint/ap/object/*"]},
+    {"Effect": "Deny", "Principal": "*", "Action": "s3:*", "Resource": ["arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"],
+     "Condition": {"Bool": {"aws:SecureTransport": "false"}}}
+  ]
+}`
+	issues, err = Lint([]byte(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues %v", issues)
+	}
+
+	if _, err = Lint([]byte(`{"Statement": []}`)); err == nil {
+		t.Fatal("expected invalid policy to fail")
+	}
+}
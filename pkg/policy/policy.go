@@ -0,0 +1,426 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// DefaultVersion is the current version of the policy language.
+const DefaultVersion = "2012-10-17"
+
+// Effect - effect of a policy statement.
+type Effect string
+
+// Effects of policy statements.
+const (
+	Allow Effect = "Allow"
+	Deny  Effect = "Deny"
+)
+
+// Principal - principals of a policy statement, by type. The wildcard
+// principal "*" matches everyone including anonymous users.
+type Principal struct {
+	AWS           set.StringSet `json:"AWS,omitempty"`
+	Service       set.StringSet `json:"Service,omitempty"`
+	Federated     set.StringSet `json:"Federated,omitempty"`
+	CanonicalUser set.StringSet `json:"CanonicalUser,omitempty"`
+}
+
+// IsWildcard - returns whether the principal is everyone.
+func (p Principal) IsWildcard() bool {
+	return p.AWS.Contains("*") || p.CanonicalUser.Contains("*")
+}
+
+// MarshalJSON encodes the wildcard principal as "*".
+func (p Principal) MarshalJSON() ([]byte, error) {
+	if len(p.AWS) == 1 && p.AWS.Contains("*") && len(p.Service) == 0 && len(p.Federated) == 0 && len(p.CanonicalUser) == 0 {
+		return []byte(`"*"`), nil
+	}
+	type aliasPrincipal Principal
+	return json.Marshal(aliasPrincipal(p))
+}
+
+// UnmarshalJSON decodes a principal object, or the wildcard principal "*".
+func (p *Principal) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		if str != "*" {
+			return errors.New("unrecognized Principal field")
+		}
+		*p = Principal{AWS: set.CreateStringSet("*")}
+		return nil
+	}
+	type aliasPrincipal Principal
+	var ap aliasPrincipal
+	if err := json.Unmarshal(data, &ap); err != nil {
+		return err
+	}
+	*p = Principal(ap)
+	return nil
+}
+
+// values - returns all principals of any type.
+func (p Principal) values() set.StringSet {
+	return p.AWS.Union(p.Service).Union(p.Federated).Union(p.CanonicalUser)
+}
+
+// PolicyStatement - statement of a policy document.
+type PolicyStatement struct {
+	Sid          string        `json:"Sid,omitempty"`
+	Effect       Effect        `json:"Effect"`
+	Principal    *Principal    `json:"Principal,omitempty"`
+	NotPrincipal *Principal    `json:"NotPrincipal,omitempty"`
+	Actions      set.StringSet `json:"Action,omitempty"`
+	NotActions   set.StringSet `json:"NotAction,omitempty"`
+	Resources    set.StringSet `json:"Resource,omitempty"`
+	NotResources set.StringSet `json:"NotResource,omitempty"`
+	Conditions   ConditionMap  `json:"Condition,omitempty"`
+}
+
+// Policy - IAM or bucket policy document.
+type Policy struct {
+	Version    string            `json:"Version,omitempty"`
+	ID         string            `json:"Id,omitempty"`
+	Statements []PolicyStatement `json:"Statement"`
+}
+
+// ParsePolicy - parses and validates a policy document.
+func ParsePolicy(data []byte) (Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Policy{}, err
+	}
+	if err := p.Validate(); err != nil {
+		return Policy{}, err
+	}
+	return p, nil
+}
+
+// UnmarshalJSON decodes a policy, whose Statement may be a single
+// statement instead of a list.
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Version   string
+		ID        string          `json:"Id"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = Policy{Version: raw.Version, ID: raw.ID}
+	statement := strings.TrimSpace(string(raw.Statement))
+	switch {
+	case statement == "" || statement == "null":
+	case strings.HasPrefix(statement, "{"):
+		var s PolicyStatement
+		if err := json.Unmarshal(raw.Statement, &s); err != nil {
+			return err
+		}
+		p.Statements = []PolicyStatement{s}
+	default:
+		if err := json.Unmarshal(raw.Statement, &p.Statements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate - returns an error if the policy is malformed.
+func (p Policy) Validate() error {
+	switch p.Version {
+	case "", DefaultVersion, "2008-10-17":
+	default:
+		return fmt.Errorf("unsupported policy version %q", p.Version)
+	}
+	if len(p.Statements) == 0 {
+		return errors.New("policy has no statements")
+	}
+	for i, s := range p.Statements {
+		if err := s.Validate(); err != nil {
+			if s.Sid != "" {
+				return fmt.Errorf("statement %q: %w", s.Sid, err)
+			}
+			return fmt.Errorf("statement %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate - returns an error if the statement is malformed.
+func (s PolicyStatement) Validate() error {
+	if s.Effect != Allow && s.Effect != Deny {
+		return fmt.Errorf("invalid effect %q", s.Effect)
+	}
+	if s.Principal != nil && s.NotPrincipal != nil {
+		return errors.New("both Principal and NotPrincipal are set")
+	}
+	if (s.Principal != nil && s.Principal.values().IsEmpty()) || (s.NotPrincipal != nil && s.NotPrincipal.values().IsEmpty()) {
+		return errors.New("principal is empty")
+	}
+	if len(s.Actions) > 0 && len(s.NotActions) > 0 {
+		return errors.New("both Action and NotAction are set")
+	}
+	if len(s.Actions) == 0 && len(s.NotActions) == 0 {
+		return errors.New("no Action or NotAction is set")
+	}
+	if len(s.Resources) > 0 && len(s.NotResources) > 0 {
+		return errors.New("both Resource and NotResource are set")
+	}
+	for op, keys := range s.Conditions {
+		if _, _, _, err := parseConditionOperator(op); err != nil {
+			return err
+		}
+		for key, values := range keys {
+			if values.IsEmpty() {
+				return fmt.Errorf("condition %s has no values for %s", op, key)
+			}
+		}
+	}
+	return nil
+}
+
+// Decision - result of evaluating a policy for a request.
+type Decision int
+
+// Decisions of policy evaluation.
+const (
+	// DecisionImplicitDeny - no statement applies to the request.
+	DecisionImplicitDeny Decision = iota
+	// DecisionAllow - an Allow statement and no Deny statement applies.
+	DecisionAllow
+	// DecisionExplicitDeny - a Deny statement applies.
+	DecisionExplicitDeny
+)
+
+// String - returns the name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case DecisionAllow:
+		return "Allow"
+	case DecisionExplicitDeny:
+		return "ExplicitDeny"
+	default:
+		return "ImplicitDeny"
+	}
+}
+
+// IsAllowed - returns whether the policy allows principal to perform
+// action on resource, see Evaluate.
+func (p Policy) IsAllowed(principal, action, resource string, values map[string][]string) bool {
+	return p.Evaluate(principal, action, resource, values) == DecisionAllow
+}
+
+// Evaluate - evaluates the policy for a request of principal to perform
+// action on resource. An applying Deny statement takes precedence over
+// Allow statements, and requests no statement applies to are implicitly
+// denied.
+//
+// principal is the ARN of the requesting user or role, an account ID, a
+// service name or "*" for anonymous requests, it is ignored by statements
+// without Principal such as those of IAM user policies. values are the
+// condition keys of the request, such as "aws:SourceIp" or "s3:prefix",
+// and are looked up ignoring case. They also replace policy variables such
+// as "${aws:username}" in resources and condition values.
+func (p Policy) Evaluate(principal, action, resource string, values map[string][]string) Decision {
+	lookup := make(map[string][]string, len(values))
+	for k, v := range values {
+		lookup[strings.ToLower(k)] = v
+	}
+
+	decision := DecisionImplicitDeny
+	for _, s := range p.Statements {
+		if !s.applies(principal, action, resource, lookup) {
+			continue
+		}
+		if s.Effect == Deny {
+			return DecisionExplicitDeny
+		}
+		if s.Effect == Allow {
+			decision = DecisionAllow
+		}
+	}
+	return decision
+}
+
+// applies - returns whether the statement applies to a request, values
+// are keyed by lower case condition keys.
+func (s PolicyStatement) applies(principal, action, resource string, values map[string][]string) bool {
+	return s.matches(principal, action, resource, values) && evalConditions(s.Conditions, values)
+}
+
+// matches - returns whether the principal, action and resource of a
+// request match the statement, regardless of its conditions.
+func (s PolicyStatement) matches(principal, action, resource string, values map[string][]string) bool {
+	switch {
+	case s.Principal != nil:
+		if !matchPrincipal(*s.Principal, principal) {
+			return false
+		}
+	case s.NotPrincipal != nil:
+		if matchPrincipal(*s.NotPrincipal, principal) {
+			return false
+		}
+	}
+
+	switch {
+	case len(s.Actions) > 0:
+		if !matchActions(s.Actions, action) {
+			return false
+		}
+	case len(s.NotActions) > 0:
+		if matchActions(s.NotActions, action) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	switch {
+	case len(s.Resources) > 0:
+		if !matchResources(s.Resources, resource, values) {
+			return false
+		}
+	case len(s.NotResources) > 0:
+		if matchResources(s.NotResources, resource, values) {
+			return false
+		}
+	}
+	return true
+}
+
+// accountIDRegexp matches AWS account IDs.
+var accountIDRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+
+// matchPrincipal - returns whether principal is one of p. Account IDs and
+// account root ARNs match all principals of the account.
+func matchPrincipal(p Principal, principal string) bool {
+	for v := range p.values() {
+		if v == "*" || wildcardMatch(v, principal, false) {
+			return true
+		}
+		account := v
+		if strings.HasPrefix(v, "arn:aws:iam::") && strings.HasSuffix(v, ":root") {
+			account = strings.TrimSuffix(strings.TrimPrefix(v, "arn:aws:iam::"), ":root")
+		}
+		if !accountIDRegexp.MatchString(account) {
+			continue
+		}
+		if principal == account ||
+			strings.HasPrefix(principal, "arn:aws:iam::"+account+":") ||
+			strings.HasPrefix(principal, "arn:aws:sts::"+account+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchActions - returns whether action matches one of the actions, which
+// are compared ignoring case.
+func matchActions(actions set.StringSet, action string) bool {
+	for a := range actions {
+		if wildcardMatch(a, action, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchResources - returns whether resource matches one of the resources
+// after substituting policy variables.
+func matchResources(resources set.StringSet, resource string, values map[string][]string) bool {
+	for r := range resources {
+		if wildcardMatch(substituteVariables(r, values), resource, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// substituteVariables - replaces policy variables such as
+// "${aws:username}" in s by the first value of the condition key, and the
+// special variables "${*}", "${?}" and "${$}" by their literal character.
+// Variables without value are kept.
+func substituteVariables(s string, values map[string][]string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(s[:start])
+		name := s[start+2 : end]
+		switch name {
+		case "*", "?", "$":
+			b.WriteString(name)
+		default:
+			if v := values[strings.ToLower(name)]; len(v) > 0 {
+				b.WriteString(v[0])
+			} else {
+				b.WriteString(s[start : end+1])
+			}
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// wildcardMatch - returns whether s matches pattern, where "*" matches any
+// sequence of characters and "?" any single character.
+func wildcardMatch(pattern, s string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+	}
+	p, i := 0, 0
+	star, match := -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, i
+			p++
+		case star >= 0:
+			p = star + 1
+			match++
+			i = match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,186 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "PublicRead",
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::bucket/public/*"
+    },
+    {
+      "Sid": "HomeDirectories",
+      "Effect": "Allow",
+      "Principal": {"AWS": ["arn:aws:iam::123456789012:root"]},
+      "Action": ["s3:GetObject", "s3:PutObject"],
+      "Resource": "arn:aws:s3:::bucket/home/${aws:username}/*"
+    },
+    {
+      "Sid": "ListHome",
+      "Effect": "Allow",
+      "Principal": {"AWS": "arn:aws:iam::123456789012:user/*"},
+      "Action": "s3:ListBucket",
+      "Resource": "arn:aws:s3:::bucket",
+      "Condition": {"StringLike": {"s3:prefix": ["home/${aws:username}/*"]}}
+    },
+    {
+      "Sid": "DenyInsecure",
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": "s3:*",
+      "Resource": ["arn:aws:s3:::bucket", "arn:aws:s3:::bucket/*"],
+      "Condition": {"Bool": {"aws:SecureTransport": "false"}}
+    },
+    {
+      "Sid": "OfficeOnly",
+      "Effect": "Deny",
+      "NotPrincipal": {"AWS": "arn:aws:iam::123456789012:user/admin"},
+      "NotAction": "s3:Get*",
+      "Resource": "arn:aws:s3:::bucket/*",
+      "Condition": {"NotIpAddress": {"aws:SourceIp": "10.0.0.0/8"}}
+    }
+  ]
+}`
+
+func TestPolicyEvaluate(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const alice = "arn:aws:iam::123456789012:user/alice"
+	secure := map[string][]string{"aws:SecureTransport": {"true"}, "aws:username": {"alice"}, "aws:SourceIp": {"10.1.2.3"}}
+	testCases := []struct {
+		principal, action, resource string
+		values                      map[string][]string
+		expected                    Decision
+	}{
+		{"*", "s3:GetObject", "arn:aws:s3:::bucket/public/a", nil, DecisionAllow},
+		{"*", "s3:GetObject", "arn:aws:s3:::bucket/private/a", nil, DecisionImplicitDeny},
+		{"*", "s3:GetObject", "arn:aws:s3:::bucket/public/a", map[string][]string{"aws:securetransport": {"false"}}, DecisionExplicitDeny},
+		{alice, "s3:PutObject", "arn:aws:s3:::bucket/home/alice/a", secure, DecisionAllow},
+		{alice, "S3:putobject", "arn:aws:s3:::bucket/home/alice/a", secure, DecisionAllow},
+		{alice, "s3:PutObject", "arn:aws:s3:::bucket/home/bob/a", secure, DecisionImplicitDeny},
+		{"arn:aws:iam::210987654321:user/alice", "s3:PutObject", "arn:aws:s3:::bucket/home/alice/a", secure, DecisionImplicitDeny},
+		{alice, "s3:ListBucket", "arn:aws:s3:::bucket", map[string][]string{"aws:username": {"alice"}, "s3:prefix": {"home/alice/"}}, DecisionAllow},
+		{alice, "s3:ListBucket", "arn:aws:s3:::bucket", map[string][]string{"aws:username": {"alice"}, "s3:prefix": {"home/bob/"}}, DecisionImplicitDeny},
+		{alice, "s3:ListBucket", "arn:aws:s3:::bucket", map[string][]string{"aws:username": {"alice"}}, DecisionImplicitDeny},
+		{alice, "s3:PutObject", "arn:aws:s3:::bucket/home/alice/a", map[string][]string{"aws:username": {"alice"}, "aws:SourceIp": {"192.168.1.1"}}, DecisionExplicitDeny},
+		{alice, "s3:GetObject", "arn:aws:s3:::bucket/home/alice/a", map[string][]string{"aws:username": {"alice"}, "aws:SourceIp": {"192.168.1.1"}}, DecisionAllow},
+		{"arn:aws:iam::123456789012:user/admin", "s3:PutObject", "arn:aws:s3:::bucket/home/admin/a", map[string][]string{"aws:username": {"admin"}, "aws:SourceIp": {"192.168.1.1"}}, DecisionAllow},
+	}
+	for i, testCase := range testCases {
+		if d := p.Evaluate(testCase.principal, testCase.action, testCase.resource, testCase.values); d != testCase.expected {
+			t.Errorf("case %d: expected %s, got %s", i+1, testCase.expected, d)
+		}
+		if allowed := p.IsAllowed(testCase.principal, testCase.action, testCase.resource, testCase.values); allowed != (testCase.expected == DecisionAllow) {
+			t.Errorf("case %d: unexpected IsAllowed %v", i+1, allowed)
+		}
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	testCases := []struct {
+		op              string
+		conditionValues []string
+		requestValues   []string
+		expected        bool
+	}{
+		{StringEquals, []string{"a", "b"}, []string{"b"}, true},
+		{StringEquals, []string{"a"}, nil, false},
+		{StringEquals + "IfExists", []string{"a"}, nil, true},
+		{StringNotEquals, []string{"a"}, nil, true},
+		{StringNotEquals, []string{"a", "b"}, []string{"b"}, false},
+		{StringEqualsIgnoreCase, []string{"ABC"}, []string{"abc"}, true},
+		{StringNotLike, []string{"a*"}, []string{"bc"}, true},
+		{NumericLessThan, []string{"10"}, []string{"9.5"}, true},
+		{NumericLessThan, []string{"10"}, []string{"10"}, false},
+		{NumericGreaterThanEquals, []string{"10"}, []string{"10"}, true},
+		{NumericEquals, []string{"x"}, []string{"10"}, false},
+		{DateLessThan, []string{"2026-01-01T00:00:00Z"}, []string{"2025-12-31T23:59:59Z"}, true},
+		{DateGreaterThan, []string{"2026-01-01T00:00:00Z"}, []string{"1767225600"}, false},
+		{DateGreaterThanEquals, []string{"2026-01-01T00:00:00Z"}, []string{"1767225600"}, true},
+		{Bool, []string{"true"}, []string{"TRUE"}, true},
+		{IPAddress, []string{"10.0.0.0/8", "192.168.1.1"}, []string{"192.168.1.1"}, true},
+		{IPAddress, []string{"2001:db8::/32"}, []string{"2001:db8::1"}, true},
+		{NotIPAddress, []string{"10.0.0.0/8"}, []string{"10.0.0.1"}, false},
+		{ArnLike, []string{"arn:aws:iam::*:role/admin-*"}, []string{"arn:aws:iam::123456789012:role/admin-x"}, true},
+		{Null, []string{"true"}, nil, true},
+		{Null, []string{"false"}, nil, false},
+		{Null, []string{"false"}, []string{"a"}, true},
+		{ForAnyValue + StringEquals, []string{"a"}, []string{"b", "a"}, true},
+		{ForAnyValue + StringEquals, []string{"a"}, nil, false},
+		{ForAllValues + StringEquals, []string{"a", "b"}, []string{"b", "a"}, true},
+		{ForAllValues + StringEquals, []string{"a"}, []string{"b", "a"}, false},
+		{ForAllValues + StringEquals, []string{"a"}, nil, true},
+		{ForAnyValue + StringNotEquals, []string{"a"}, []string{"a", "b"}, true},
+		{"StringSimilar", []string{"a"}, []string{"a"}, false},
+	}
+	for i, testCase := range testCases {
+		if result := evalCondition(testCase.op, testCase.conditionValues, testCase.requestValues, nil); result != testCase.expected {
+			t.Errorf("case %d: %s: expected %v, got %v", i+1, testCase.op, testCase.expected, result)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	testCases := []struct {
+		policy  string
+		success bool
+	}{
+		{`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:*","Resource":"*"}}`, true},
+		{`{"Version":"2012-10-17","Statement":[]}`, false},
+		{`{"Version":"2020-01-01","Statement":{"Effect":"Allow","Action":"s3:*"}}`, false},
+		{`{"Statement":{"Effect":"Permit","Action":"s3:*"}}`, false},
+		{`{"Statement":{"Effect":"Allow","Resource":"*"}}`, false},
+		{`{"Statement":{"Effect":"Allow","Action":"s3:*","NotAction":"s3:GetObject"}}`, false},
+		{`{"Statement":{"Effect":"Allow","Principal":"alice","Action":"s3:*"}}`, false},
+		{`{"Statement":{"Effect":"Allow","Action":"s3:*","Condition":{"StringSimilar":{"s3:prefix":"a"}}}}`, false},
+	}
+	for i, testCase := range testCases {
+		_, err := ParsePolicy([]byte(testCase.policy))
+		if (err == nil) != testCase.success {
+			t.Errorf("case %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	p, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := ParsePolicy(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Statements) != len(p.Statements) || !q.Statements[0].Principal.IsWildcard() || q.Statements[4].NotPrincipal == nil {
+		t.Fatalf("unexpected policy after round trip: %s", data)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+const (
+	versioningEnabled   = "Enabled"
+	versioningSuspended = "Suspended"
+
+	// nullVersionID is the version ID of objects written while
+	// versioning is not enabled.
+	nullVersionID = "null"
+)
+
+// bucket is an in-memory bucket.
+type bucket struct {
+	name       string
+	created    time.Time
+	versioning string
+	objectLock bool
+	lockConfig *objectLockRule
+	tags       *tags.Tags
+
+	// objects maps object names to their versions, newest first.
+	objects map[string][]*version
+	uploads map[string]*upload
+}
+
+// objectMeta is the metadata of an object, set on upload.
+type objectMeta struct {
+	header http.Header
+	tags   *tags.Tags
+
+	retentionMode string
+	retainUntil   time.Time
+	legalHold     bool
+}
+
+// version is a single version of an object or a delete marker.
+type version struct {
+	objectMeta
+
+	id           string
+	deleteMarker bool
+	data         []byte
+	etag         string
+	modTime      time.Time
+}
+
+// latest returns the current version of objectName.
+func (b *bucket) latest(objectName string) *version {
+	if versions := b.objects[objectName]; len(versions) > 0 {
+		return versions[0]
+	}
+	return nil
+}
+
+// lookup returns the requested version of objectName, the latest version
+// if versionID is empty. Delete markers are returned as errors.
+func (b *bucket) lookup(objectName, versionID string) (*version, apiError, bool) {
+	if versionID == "" {
+		v := b.latest(objectName)
+		if v == nil || v.deleteMarker {
+			return nil, errNoSuchKey, false
+		}
+		return v, apiError{}, true
+	}
+	for _, v := range b.objects[objectName] {
+		if v.id == versionID {
+			if v.deleteMarker {
+				return nil, errMethodNotAllowed, false
+			}
+			return v, apiError{}, true
+		}
+	}
+	return nil, errNoSuchVersion, false
+}
+
+// add stores v as the latest version of objectName. Unless versioning is
+// enabled v replaces the existing null version.
+func (b *bucket) add(objectName string, v *version) {
+	versions := b.objects[objectName]
+	if b.versioning != versioningEnabled {
+		v.id = nullVersionID
+		versions = slices.DeleteFunc(versions, func(o *version) bool {
+			return o.id == nullVersionID
+		})
+	} else {
+		v.id = newVersionID()
+	}
+	b.objects[objectName] = append([]*version{v}, versions...)
+}
+
+// remove deletes versionID of objectName.
+func (b *bucket) remove(objectName, versionID string) {
+	versions := slices.DeleteFunc(b.objects[objectName], func(v *version) bool {
+		return v.id == versionID
+	})
+	if len(versions) == 0 {
+		delete(b.objects, objectName)
+		return
+	}
+	b.objects[objectName] = versions
+}
+
+// locked returns an error if v may not be deleted at now.
+func (v *version) locked(r *http.Request, now time.Time) (apiError, bool) {
+	if v.legalHold {
+		return errObjectLockedByRetention, true
+	}
+	if v.retainUntil.IsZero() || !now.Before(v.retainUntil) {
+		return apiError{}, false
+	}
+	if v.retentionMode == "GOVERNANCE" && strings.EqualFold(r.Header.Get("X-Amz-Bypass-Governance-Retention"), "true") {
+		return apiError{}, false
+	}
+	return errObjectLockedByRetention, true
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter) {
+	result := listAllMyBucketsResult{Owner: defaultOwner}
+	for _, b := range s.buckets {
+		result.Buckets.Bucket = append(result.Buckets.Bucket, bucketInfo{
+			Name:         b.name,
+			CreationDate: b.created,
+		})
+	}
+	slices.SortFunc(result.Buckets.Bucket, func(a, b bucketInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) makeBucket(w http.ResponseWriter, r *http.Request, bucketName string) {
+	if _, ok := s.buckets[bucketName]; ok {
+		writeError(w, errBucketAlreadyOwnedByYou, bucketName, "")
+		return
+	}
+	// The location constraint is accepted but not recorded.
+	if _, err := readBody(r); err != nil {
+		writeError(w, errMalformedXML, bucketName, "")
+		return
+	}
+	b := &bucket{
+		name:    bucketName,
+		created: s.now().UTC(),
+		objects: make(map[string][]*version),
+		uploads: make(map[string]*upload),
+	}
+	if strings.EqualFold(r.Header.Get("X-Amz-Bucket-Object-Lock-Enabled"), "true") {
+		b.objectLock = true
+		b.versioning = versioningEnabled
+	}
+	s.buckets[bucketName] = b
+	w.Header().Set("Location", "/"+bucketName)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) removeBucket(w http.ResponseWriter, b *bucket) {
+	if len(b.objects) > 0 {
+		writeError(w, errBucketNotEmpty, b.name, "")
+		return
+	}
+	delete(s.buckets, b.name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) putBucketVersioning(w http.ResponseWriter, r *http.Request, b *bucket) {
+	var config versioningConfiguration
+	if err := decodeXML(r, &config); err != nil {
+		writeError(w, errMalformedXML, b.name, "")
+		return
+	}
+	switch config.Status {
+	case versioningEnabled:
+	case versioningSuspended:
+		if b.objectLock {
+			writeError(w, errInvalidBucketState, b.name, "")
+			return
+		}
+	default:
+		writeError(w, errMalformedXML, b.name, "")
+		return
+	}
+	b.versioning = config.Status
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getObjectLockConfig(w http.ResponseWriter, b *bucket) {
+	if !b.objectLock {
+		writeError(w, errNoSuchLockConfiguration, b.name, "")
+		return
+	}
+	writeXML(w, http.StatusOK, objectLockConfiguration{
+		ObjectLockEnabled: versioningEnabled,
+		Rule:              b.lockConfig,
+	})
+}
+
+func (s *Server) putObjectLockConfig(w http.ResponseWriter, r *http.Request, b *bucket) {
+	var config objectLockConfiguration
+	if err := decodeXML(r, &config); err != nil || config.ObjectLockEnabled != versioningEnabled {
+		writeError(w, errMalformedXML, b.name, "")
+		return
+	}
+	if !b.objectLock {
+		writeError(w, errInvalidBucketState, b.name, "")
+		return
+	}
+	if config.Rule != nil {
+		retention := config.Rule.DefaultRetention
+		if !validRetentionMode(retention.Mode) || (retention.Days > 0) == (retention.Years > 0) {
+			writeError(w, errMalformedXML, b.name, "")
+			return
+		}
+	}
+	b.lockConfig = config.Rule
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getBucketTagging(w http.ResponseWriter, b *bucket) {
+	if b.tags == nil {
+		writeError(w, errNoSuchTagSet, b.name, "")
+		return
+	}
+	writeXML(w, http.StatusOK, b.tags)
+}
+
+func (s *Server) putBucketTagging(w http.ResponseWriter, r *http.Request, b *bucket) {
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, errMalformedXML, b.name, "")
+		return
+	}
+	t, err := tags.ParseBucketXML(bytes.NewReader(data))
+	if err != nil {
+		writeError(w, errInvalidArgument, b.name, "")
+		return
+	}
+	b.tags = t
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validRetentionMode returns true for the supported object lock modes.
+func validRetentionMode(mode string) bool {
+	return mode == "GOVERNANCE" || mode == "COMPLIANCE"
+}
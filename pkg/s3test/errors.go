@@ -0,0 +1,79 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// apiError is an S3 error code with its HTTP status.
+type apiError struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+var (
+	errAccessDenied                = apiError{"AccessDenied", "Access Denied.", http.StatusForbidden}
+	errBucketAlreadyOwnedByYou     = apiError{"BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.", http.StatusConflict}
+	errBucketNotEmpty              = apiError{"BucketNotEmpty", "The bucket you tried to delete is not empty.", http.StatusConflict}
+	errEntityTooSmall              = apiError{"EntityTooSmall", "Your proposed upload is smaller than the minimum allowed object size.", http.StatusBadRequest}
+	errInvalidArgument             = apiError{"InvalidArgument", "Invalid argument.", http.StatusBadRequest}
+	errInvalidBucketState          = apiError{"InvalidBucketState", "The request is not valid with the current state of the bucket.", http.StatusConflict}
+	errInvalidPart                 = apiError{"InvalidPart", "One or more of the specified parts could not be found.", http.StatusBadRequest}
+	errInvalidPartOrder            = apiError{"InvalidPartOrder", "The list of parts was not in ascending order.", http.StatusBadRequest}
+	errInvalidRange                = apiError{"InvalidRange", "The requested range is not satisfiable.", http.StatusRequestedRangeNotSatisfiable}
+	errInvalidRequest              = apiError{"InvalidRequest", "Bucket is missing Object Lock Configuration.", http.StatusBadRequest}
+	errMalformedXML                = apiError{"MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.", http.StatusBadRequest}
+	errMethodNotAllowed            = apiError{"MethodNotAllowed", "The specified method is not allowed against this resource.", http.StatusMethodNotAllowed}
+	errNoSuchBucket                = apiError{"NoSuchBucket", "The specified bucket does not exist.", http.StatusNotFound}
+	errNoSuchKey                   = apiError{"NoSuchKey", "The specified key does not exist.", http.StatusNotFound}
+	errNoSuchLockConfiguration     = apiError{"ObjectLockConfigurationNotFoundError", "Object Lock configuration does not exist for this bucket.", http.StatusNotFound}
+	errNoSuchObjectLockConfig      = apiError{"NoSuchObjectLockConfiguration", "The specified object does not have a ObjectLock configuration.", http.StatusNotFound}
+	errNoSuchTagSet                = apiError{"NoSuchTagSet", "The TagSet does not exist.", http.StatusNotFound}
+	errNoSuchUpload                = apiError{"NoSuchUpload", "The specified multipart upload does not exist.", http.StatusNotFound}
+	errNoSuchVersion               = apiError{"NoSuchVersion", "The specified version does not exist.", http.StatusNotFound}
+	errNotImplemented              = apiError{"NotImplemented", "A header you provided implies functionality that is not implemented.", http.StatusNotImplemented}
+	errPreconditionFailed          = apiError{"PreconditionFailed", "At least one of the pre-conditions you specified did not hold.", http.StatusPreconditionFailed}
+	errObjectLockedByRetention     = apiError{"AccessDenied", "Object is WORM protected and cannot be overwritten or deleted.", http.StatusForbidden}
+	errInvalidRetentionPeriodShort = apiError{"AccessDenied", "The retention period cannot be shortened.", http.StatusForbidden}
+)
+
+// errorResponse is the XML error body.
+type errorResponse struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string
+	Message    string
+	BucketName string `xml:",omitempty"`
+	Key        string `xml:",omitempty"`
+	RequestID  string `xml:"RequestId"`
+	HostID     string `xml:"HostId"`
+}
+
+// writeError writes err as XML error response.
+func writeError(w http.ResponseWriter, err apiError, bucketName, objectName string) {
+	writeXML(w, err.StatusCode, errorResponse{
+		Code:       err.Code,
+		Message:    err.Message,
+		BucketName: bucketName,
+		Key:        objectName,
+		RequestID:  "s3test",
+		HostID:     "s3test",
+	})
+}
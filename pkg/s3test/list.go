@@ -0,0 +1,219 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// commonPrefixOf returns the common prefix objectName is rolled up into
+// when listing prefix with delimiter.
+func commonPrefixOf(objectName, prefix, delimiter string) (string, bool) {
+	if delimiter == "" {
+		return "", false
+	}
+	i := strings.Index(objectName[len(prefix):], delimiter)
+	if i < 0 {
+		return "", false
+	}
+	return objectName[:len(prefix)+i+len(delimiter)], true
+}
+
+// sortedKeys returns the object names with prefix after marker in
+// lexical order.
+func (b *bucket) sortedKeys(prefix, marker string) []string {
+	keys := make([]string, 0, len(b.objects))
+	for k := range b.objects {
+		if strings.HasPrefix(k, prefix) && k > marker {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// listLatest lists the latest versions of the objects with prefix after
+// marker, rolling up common prefixes. It returns the marker to continue
+// from if the listing is truncated.
+func (b *bucket) listLatest(prefix, delimiter, marker string, maxKeys int, fetchOwner bool) (contents []objectContent, prefixes []commonPrefix, next string) {
+	for _, k := range b.sortedKeys(prefix, marker) {
+		v := b.latest(k)
+		if v.deleteMarker {
+			continue
+		}
+		cp, isPrefix := commonPrefixOf(k, prefix, delimiter)
+		if isPrefix && (cp <= marker || len(prefixes) > 0 && prefixes[len(prefixes)-1].Prefix == cp) {
+			continue
+		}
+		if len(contents)+len(prefixes) == maxKeys {
+			return contents, prefixes, next
+		}
+		if isPrefix {
+			prefixes = append(prefixes, commonPrefix{Prefix: cp})
+			next = cp
+			continue
+		}
+		content := objectContent{
+			Key:          k,
+			LastModified: v.modTime,
+			ETag:         "\"" + v.etag + "\"",
+			Size:         int64(len(v.data)),
+			StorageClass: "STANDARD",
+		}
+		if fetchOwner {
+			content.Owner = &defaultOwner
+		}
+		contents = append(contents, content)
+		next = k
+	}
+	return contents, prefixes, ""
+}
+
+func (s *Server) listObjectsV1(w http.ResponseWriter, b *bucket, query url.Values) {
+	maxKeys, ok := parseMaxKeys(query, "max-keys")
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, "")
+		return
+	}
+	result := listBucketResult{
+		Name:      b.name,
+		Prefix:    query.Get("prefix"),
+		Marker:    query.Get("marker"),
+		MaxKeys:   maxKeys,
+		Delimiter: query.Get("delimiter"),
+	}
+	result.Contents, result.CommonPrefixes, result.NextMarker = b.listLatest(result.Prefix, result.Delimiter, result.Marker, maxKeys, true)
+	result.IsTruncated = result.NextMarker != ""
+	writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) listObjectsV2(w http.ResponseWriter, b *bucket, query url.Values) {
+	maxKeys, ok := parseMaxKeys(query, "max-keys")
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, "")
+		return
+	}
+	result := listBucketV2Result{
+		Name:              b.name,
+		Prefix:            query.Get("prefix"),
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		MaxKeys:           maxKeys,
+		Delimiter:         query.Get("delimiter"),
+	}
+	// The continuation token is the last key or common prefix returned.
+	marker := result.StartAfter
+	if result.ContinuationToken != "" {
+		marker = result.ContinuationToken
+	}
+	result.Contents, result.CommonPrefixes, result.NextContinuationToken = b.listLatest(result.Prefix, result.Delimiter, marker, maxKeys, query.Get("fetch-owner") == "true")
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+	result.IsTruncated = result.NextContinuationToken != ""
+	writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) listObjectVersions(w http.ResponseWriter, b *bucket, query url.Values) {
+	maxKeys, ok := parseMaxKeys(query, "max-keys")
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, "")
+		return
+	}
+	result := listVersionsResult{
+		Name:            b.name,
+		Prefix:          query.Get("prefix"),
+		KeyMarker:       query.Get("key-marker"),
+		VersionIDMarker: query.Get("version-id-marker"),
+		MaxKeys:         maxKeys,
+		Delimiter:       query.Get("delimiter"),
+	}
+
+	// Versions of the key marker after the version ID marker are
+	// listed first.
+	keyMarker := result.KeyMarker
+	keys := b.sortedKeys(result.Prefix, keyMarker)
+	if result.VersionIDMarker != "" && keyMarker != "" && strings.HasPrefix(keyMarker, result.Prefix) {
+		keys = append([]string{keyMarker}, keys...)
+	}
+
+	var count int
+	for _, k := range keys {
+		cp, isPrefix := commonPrefixOf(k, result.Prefix, result.Delimiter)
+		if isPrefix {
+			if cp <= keyMarker || len(result.CommonPrefixes) > 0 && result.CommonPrefixes[len(result.CommonPrefixes)-1].Prefix == cp {
+				continue
+			}
+			if count == maxKeys {
+				result.IsTruncated = true
+				break
+			}
+			count++
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: cp})
+			result.NextKeyMarker, result.NextVersionIDMarker = cp, ""
+			continue
+		}
+
+		versions := b.objects[k]
+		first := 0
+		if k == keyMarker {
+			first = len(versions)
+			for i, v := range versions {
+				if v.id == result.VersionIDMarker {
+					first = i + 1
+					break
+				}
+			}
+		}
+		for i := first; i < len(versions); i++ {
+			if count == maxKeys {
+				result.IsTruncated = true
+				break
+			}
+			count++
+			v := versions[i]
+			entry := versionEntry{
+				XMLName:      xml.Name{Local: "Version"},
+				Key:          k,
+				VersionID:    v.id,
+				IsLatest:     i == 0,
+				LastModified: v.modTime,
+				Owner:        defaultOwner,
+			}
+			if v.deleteMarker {
+				entry.XMLName.Local = "DeleteMarker"
+			} else {
+				size := int64(len(v.data))
+				entry.ETag = "\"" + v.etag + "\""
+				entry.Size = &size
+				entry.StorageClass = "STANDARD"
+			}
+			result.Entries = append(result.Entries, entry)
+			result.NextKeyMarker, result.NextVersionIDMarker = k, v.id
+		}
+		if result.IsTruncated {
+			break
+		}
+	}
+	if !result.IsTruncated {
+		result.NextKeyMarker, result.NextVersionIDMarker = "", ""
+	}
+	writeXML(w, http.StatusOK, result)
+}
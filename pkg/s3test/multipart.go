@@ -0,0 +1,315 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// minPartSize is the minimum size of all but the last part.
+	minPartSize = 5 * 1024 * 1024
+
+	maxPartNumber = 10000
+)
+
+// upload is an incomplete multipart upload.
+type upload struct {
+	objectMeta
+
+	id         string
+	objectName string
+	initiated  time.Time
+	parts      map[int]*part
+}
+
+// part is an uploaded part of a multipart upload.
+type part struct {
+	data    []byte
+	etag    string
+	modTime time.Time
+}
+
+// lookupUpload returns the upload addressed by the uploadId query
+// parameter or writes the error response.
+func lookupUpload(w http.ResponseWriter, b *bucket, objectName string, query url.Values) (*upload, bool) {
+	u, ok := b.uploads[query.Get("uploadId")]
+	if !ok || u.objectName != objectName {
+		writeError(w, errNoSuchUpload, b.name, objectName)
+		return nil, false
+	}
+	return u, true
+}
+
+// parseMaxKeys parses the limit query parameter name, returning 1000 if it
+// is not set.
+func parseMaxKeys(query url.Values, name string) (int, bool) {
+	if !query.Has(name) {
+		return 1000, true
+	}
+	n, err := strconv.Atoi(query.Get(name))
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return min(n, 1000), true
+}
+
+func (s *Server) newMultipartUpload(w http.ResponseWriter, r *http.Request, b *bucket, objectName string) {
+	now := s.now().UTC()
+	meta, apiErr, ok := parseObjectMeta(r, b, now)
+	if !ok {
+		writeError(w, apiErr, b.name, objectName)
+		return
+	}
+	u := &upload{
+		objectMeta: meta,
+		id:         newVersionID(),
+		objectName: objectName,
+		initiated:  now,
+		parts:      make(map[int]*part),
+	}
+	b.uploads[u.id] = u
+	writeXML(w, http.StatusOK, initiateMultipartUploadResult{
+		Bucket:   b.name,
+		Key:      objectName,
+		UploadID: u.id,
+	})
+}
+
+func (s *Server) putObjectPart(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	u, ok := lookupUpload(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		writeError(w, errInvalidArgument, b.name, objectName)
+		return
+	}
+
+	p := &part{modTime: s.now().UTC()}
+	if r.Header.Get("X-Amz-Copy-Source") == "" {
+		if p.data, err = readBody(r); err != nil {
+			writeError(w, errInvalidRequest, b.name, objectName)
+			return
+		}
+		p.etag = md5ETag(p.data)
+		u.parts[partNumber] = p
+		w.Header().Set("ETag", "\""+p.etag+"\"")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	src, ok := s.copySource(w, r, b, objectName)
+	if !ok {
+		return
+	}
+	p.data = src.data
+	if rng := r.Header.Get("X-Amz-Copy-Source-Range"); rng != "" {
+		start, end, ranged, ok := parseRange(rng, int64(len(src.data)))
+		if !ranged || !ok {
+			writeError(w, errInvalidRange, b.name, objectName)
+			return
+		}
+		p.data = src.data[start:end]
+	}
+	p.etag = md5ETag(p.data)
+	u.parts[partNumber] = p
+	writeXML(w, http.StatusOK, copyPartResult{
+		ETag:         "\"" + p.etag + "\"",
+		LastModified: p.modTime,
+	})
+}
+
+func (s *Server) completeMultipartUpload(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	u, ok := lookupUpload(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	var req completeMultipartUpload
+	if err := decodeXML(r, &req); err != nil || len(req.Parts) == 0 {
+		writeError(w, errMalformedXML, b.name, objectName)
+		return
+	}
+
+	var data, sums []byte
+	for i, cp := range req.Parts {
+		if i > 0 && cp.PartNumber <= req.Parts[i-1].PartNumber {
+			writeError(w, errInvalidPartOrder, b.name, objectName)
+			return
+		}
+		p, ok := u.parts[cp.PartNumber]
+		if !ok || strings.Trim(cp.ETag, "\"") != p.etag {
+			writeError(w, errInvalidPart, b.name, objectName)
+			return
+		}
+		if i < len(req.Parts)-1 && len(p.data) < minPartSize {
+			writeError(w, errEntityTooSmall, b.name, objectName)
+			return
+		}
+		sum, _ := hex.DecodeString(p.etag)
+		sums = append(sums, sum...)
+		data = append(data, p.data...)
+	}
+	sum := md5.Sum(sums)
+
+	v := &version{
+		objectMeta: u.objectMeta,
+		data:       data,
+		etag:       fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(req.Parts)),
+		modTime:    s.now().UTC(),
+	}
+	b.add(objectName, v)
+	delete(b.uploads, u.id)
+	setVersionHeader(w, b, v.id)
+	writeXML(w, http.StatusOK, completeMultipartUploadResult{
+		Location: "/" + b.name + "/" + objectName,
+		Bucket:   b.name,
+		Key:      objectName,
+		ETag:     "\"" + v.etag + "\"",
+	})
+}
+
+func (s *Server) abortMultipartUpload(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	u, ok := lookupUpload(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	delete(b.uploads, u.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listObjectParts(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	u, ok := lookupUpload(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	maxParts, ok := parseMaxKeys(query, "max-parts")
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, objectName)
+		return
+	}
+	marker, _ := strconv.Atoi(query.Get("part-number-marker"))
+
+	result := listPartsResult{
+		Bucket:           b.name,
+		Key:              objectName,
+		UploadID:         u.id,
+		Initiator:        defaultOwner,
+		Owner:            defaultOwner,
+		StorageClass:     "STANDARD",
+		PartNumberMarker: marker,
+		MaxParts:         maxParts,
+	}
+	numbers := slices.Sorted(func(yield func(int) bool) {
+		for n := range u.parts {
+			if n > marker && !yield(n) {
+				return
+			}
+		}
+	})
+	if len(numbers) > maxParts {
+		numbers = numbers[:maxParts]
+		result.IsTruncated = true
+	}
+	for _, n := range numbers {
+		p := u.parts[n]
+		result.Parts = append(result.Parts, partInfo{
+			PartNumber:   n,
+			LastModified: p.modTime,
+			ETag:         "\"" + p.etag + "\"",
+			Size:         int64(len(p.data)),
+		})
+		result.NextPartNumberMarker = n
+	}
+	writeXML(w, http.StatusOK, result)
+}
+
+func (s *Server) listMultipartUploads(w http.ResponseWriter, b *bucket, query url.Values) {
+	maxUploads, ok := parseMaxKeys(query, "max-uploads")
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, "")
+		return
+	}
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+	keyMarker, uploadIDMarker := query.Get("key-marker"), query.Get("upload-id-marker")
+
+	uploads := make([]*upload, 0, len(b.uploads))
+	for _, u := range b.uploads {
+		if !strings.HasPrefix(u.objectName, prefix) {
+			continue
+		}
+		if u.objectName < keyMarker || (u.objectName == keyMarker && (uploadIDMarker == "" || u.id <= uploadIDMarker)) {
+			continue
+		}
+		uploads = append(uploads, u)
+	}
+	slices.SortFunc(uploads, func(a, b *upload) int {
+		if c := strings.Compare(a.objectName, b.objectName); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	result := listMultipartUploadsResult{
+		Bucket:         b.name,
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
+		MaxUploads:     maxUploads,
+	}
+	var count int
+	for _, u := range uploads {
+		cp, isPrefix := commonPrefixOf(u.objectName, prefix, delimiter)
+		if isPrefix && (cp <= keyMarker || len(result.CommonPrefixes) > 0 && result.CommonPrefixes[len(result.CommonPrefixes)-1].Prefix == cp) {
+			continue
+		}
+		if count == maxUploads {
+			result.IsTruncated = true
+			break
+		}
+		count++
+		if isPrefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: cp})
+			result.NextKeyMarker, result.NextUploadIDMarker = cp, ""
+			continue
+		}
+		result.Uploads = append(result.Uploads, uploadInfo{
+			Key:          u.objectName,
+			UploadID:     u.id,
+			Initiator:    defaultOwner,
+			Owner:        defaultOwner,
+			StorageClass: "STANDARD",
+			Initiated:    u.initiated,
+		})
+		result.NextKeyMarker, result.NextUploadIDMarker = u.objectName, u.id
+	}
+	if !result.IsTruncated {
+		result.NextKeyMarker, result.NextUploadIDMarker = "", ""
+	}
+	writeXML(w, http.StatusOK, result)
+}
@@ -0,0 +1,609 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// storedHeaders are the request headers stored as object metadata, in
+// addition to all X-Amz-Meta-* headers.
+var storedHeaders = []string{
+	"Content-Type",
+	"Content-Encoding",
+	"Content-Disposition",
+	"Content-Language",
+	"Cache-Control",
+	"Expires",
+	"X-Amz-Storage-Class",
+	"X-Amz-Website-Redirect-Location",
+}
+
+// parseObjectMeta returns the object metadata set by the request headers.
+func parseObjectMeta(r *http.Request, b *bucket, now time.Time) (objectMeta, apiError, bool) {
+	meta := objectMeta{header: make(http.Header)}
+	for _, k := range storedHeaders {
+		if v := r.Header.Get(k); v != "" {
+			meta.header.Set(k, v)
+		}
+	}
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, "X-Amz-Meta-") {
+			meta.header[k] = v
+		}
+	}
+	if meta.header.Get("Content-Type") == "" {
+		meta.header.Set("Content-Type", "binary/octet-stream")
+	}
+
+	if v := r.Header.Get("X-Amz-Tagging"); v != "" {
+		t, err := tags.ParseObjectTags(v)
+		if err != nil {
+			return meta, errInvalidArgument, false
+		}
+		meta.tags = t
+	}
+
+	mode := r.Header.Get("X-Amz-Object-Lock-Mode")
+	until := r.Header.Get("X-Amz-Object-Lock-Retain-Until-Date")
+	hold := r.Header.Get("X-Amz-Object-Lock-Legal-Hold")
+	if mode == "" && until == "" && hold == "" {
+		if b.lockConfig != nil {
+			retention := b.lockConfig.DefaultRetention
+			meta.retentionMode = retention.Mode
+			meta.retainUntil = now.AddDate(retention.Years, 0, retention.Days)
+		}
+		return meta, apiError{}, true
+	}
+	if !b.objectLock {
+		return meta, errInvalidRequest, false
+	}
+	if (mode == "") != (until == "") {
+		return meta, errInvalidArgument, false
+	}
+	if mode != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil || !validRetentionMode(mode) || !t.After(now) {
+			return meta, errInvalidArgument, false
+		}
+		meta.retentionMode = mode
+		meta.retainUntil = t
+	}
+	switch hold {
+	case "", "OFF":
+	case "ON":
+		meta.legalHold = true
+	default:
+		return meta, errInvalidArgument, false
+	}
+	return meta, apiError{}, true
+}
+
+// md5ETag returns the hex encoded MD5 sum of data.
+func md5ETag(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// setVersionHeader sets the version ID response header on versioned
+// buckets.
+func setVersionHeader(w http.ResponseWriter, b *bucket, versionID string) {
+	if b.versioning != "" {
+		w.Header().Set("X-Amz-Version-Id", versionID)
+	}
+}
+
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, b *bucket, objectName string) {
+	now := s.now().UTC()
+	meta, apiErr, ok := parseObjectMeta(r, b, now)
+	if !ok {
+		writeError(w, apiErr, b.name, objectName)
+		return
+	}
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, errInvalidRequest, b.name, objectName)
+		return
+	}
+	if contentMD5 := r.Header.Get("Content-Md5"); contentMD5 != "" {
+		sum := md5.Sum(data)
+		if contentMD5 != base64.StdEncoding.EncodeToString(sum[:]) {
+			writeError(w, apiError{"BadDigest", "The Content-MD5 you specified did not match what we received.", http.StatusBadRequest}, b.name, objectName)
+			return
+		}
+	}
+
+	v := &version{
+		objectMeta: meta,
+		data:       data,
+		etag:       md5ETag(data),
+		modTime:    now,
+	}
+	b.add(objectName, v)
+	setVersionHeader(w, b, v.id)
+	w.Header().Set("ETag", "\""+v.etag+"\"")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	versionID := query.Get("versionId")
+	v, apiErr, ok := b.lookup(objectName, versionID)
+	if !ok {
+		latest := b.latest(objectName)
+		if versionID == "" && latest != nil {
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			setVersionHeader(w, b, latest.id)
+		} else if apiErr == errMethodNotAllowed {
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			setVersionHeader(w, b, versionID)
+		}
+		writeError(w, apiErr, b.name, objectName)
+		return
+	}
+
+	if status := checkPreconditions(r.Header, v, ""); status != 0 {
+		if status == http.StatusNotModified {
+			w.Header().Set("ETag", "\""+v.etag+"\"")
+			w.WriteHeader(status)
+			return
+		}
+		writeError(w, errPreconditionFailed, b.name, objectName)
+		return
+	}
+
+	h := w.Header()
+	for k, vv := range v.header {
+		h[k] = vv
+	}
+	for k, vv := range query {
+		// response-content-type overrides Content-Type and so on.
+		if name, ok := strings.CutPrefix(k, "response-"); ok {
+			h.Set(name, vv[0])
+		}
+	}
+	h.Set("ETag", "\""+v.etag+"\"")
+	h.Set("Last-Modified", v.modTime.Format(http.TimeFormat))
+	h.Set("Accept-Ranges", "bytes")
+	setVersionHeader(w, b, v.id)
+	if v.tags != nil && v.tags.Count() > 0 {
+		h.Set("X-Amz-Tagging-Count", strconv.Itoa(v.tags.Count()))
+	}
+	if v.retentionMode != "" {
+		h.Set("X-Amz-Object-Lock-Mode", v.retentionMode)
+		h.Set("X-Amz-Object-Lock-Retain-Until-Date", v.retainUntil.Format(time.RFC3339))
+	}
+	if b.objectLock {
+		h.Set("X-Amz-Object-Lock-Legal-Hold", legalHoldStatus(v.legalHold))
+	}
+
+	size := int64(len(v.data))
+	start, end, ranged, ok := parseRange(r.Header.Get("Range"), size)
+	if !ok {
+		h.Set("Content-Range", "bytes */"+strconv.FormatInt(size, 10))
+		writeError(w, errInvalidRange, b.name, objectName)
+		return
+	}
+	statusCode := http.StatusOK
+	if ranged {
+		h.Set("Content-Range", "bytes "+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(end-1, 10)+"/"+strconv.FormatInt(size, 10))
+		statusCode = http.StatusPartialContent
+	}
+	h.Set("Content-Length", strconv.FormatInt(end-start, 10))
+	w.WriteHeader(statusCode)
+	if r.Method != http.MethodHead {
+		w.Write(v.data[start:end])
+	}
+}
+
+// checkPreconditions evaluates the conditional request headers against v,
+// using the header names with the given prefix. It returns 0 if the
+// request may proceed, the response status code otherwise.
+func checkPreconditions(header http.Header, v *version, prefix string) int {
+	etagMatches := func(list string) bool {
+		for _, etag := range strings.Split(list, ",") {
+			etag = strings.Trim(strings.TrimPrefix(strings.TrimSpace(etag), "W/"), "\"")
+			if etag == "*" || etag == v.etag {
+				return true
+			}
+		}
+		return false
+	}
+	modTime := v.modTime.Truncate(time.Second)
+
+	if im := header.Get(prefix + "If-Match"); im != "" {
+		if !etagMatches(im) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ius := header.Get(prefix + "If-Unmodified-Since"); ius != "" {
+		if t, err := http.ParseTime(ius); err == nil && modTime.After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	// Copy requests fail with 412 instead of 304.
+	notModified := http.StatusNotModified
+	if prefix != "" {
+		notModified = http.StatusPreconditionFailed
+	}
+	if inm := header.Get(prefix + "If-None-Match"); inm != "" {
+		if etagMatches(inm) {
+			return notModified
+		}
+	} else if ims := header.Get(prefix + "If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil && !modTime.After(t) {
+			return notModified
+		}
+	}
+	return 0
+}
+
+// parseRange parses a single byte range of the form "bytes=start-end",
+// "bytes=start-" or "bytes=-suffix" and returns the half open interval
+// [start, end). Missing or malformed ranges select the whole object, ok is
+// false for unsatisfiable ranges.
+func parseRange(rng string, size int64) (start, end int64, ranged, ok bool) {
+	spec, found := strings.CutPrefix(rng, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, size, false, true
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, size, false, true
+	}
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, size, false, true
+		}
+		if size == 0 {
+			return 0, 0, false, false
+		}
+		return max(size-n, 0), size, true, true
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, size, false, true
+	}
+	end = size
+	if last != "" {
+		e, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || e < start {
+			return 0, size, false, true
+		}
+		end = min(e+1, size)
+	}
+	if start >= size {
+		return 0, 0, false, false
+	}
+	return start, end, true, true
+}
+
+// parseCopySource returns the source bucket, object and version ID from
+// the X-Amz-Copy-Source header.
+func parseCopySource(r *http.Request) (bucketName, objectName, versionID string, ok bool) {
+	source, rawQuery, _ := strings.Cut(r.Header.Get("X-Amz-Copy-Source"), "?")
+	source, err := url.PathUnescape(source)
+	if err != nil {
+		return "", "", "", false
+	}
+	bucketName, objectName, ok = strings.Cut(strings.TrimPrefix(source, "/"), "/")
+	if !ok || bucketName == "" || objectName == "" {
+		return "", "", "", false
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", "", "", false
+	}
+	return bucketName, objectName, query.Get("versionId"), true
+}
+
+// copySource returns the source version of a copy request.
+func (s *Server) copySource(w http.ResponseWriter, r *http.Request, b *bucket, objectName string) (*version, bool) {
+	srcBucketName, srcObjectName, srcVersionID, ok := parseCopySource(r)
+	if !ok {
+		writeError(w, errInvalidArgument, b.name, objectName)
+		return nil, false
+	}
+	srcBucket, ok := s.buckets[srcBucketName]
+	if !ok {
+		writeError(w, errNoSuchBucket, srcBucketName, "")
+		return nil, false
+	}
+	src, apiErr, ok := srcBucket.lookup(srcObjectName, srcVersionID)
+	if !ok {
+		if apiErr == errMethodNotAllowed {
+			apiErr = errInvalidRequest
+		}
+		writeError(w, apiErr, srcBucketName, srcObjectName)
+		return nil, false
+	}
+	if checkPreconditions(r.Header, src, "X-Amz-Copy-Source-") != 0 {
+		writeError(w, errPreconditionFailed, b.name, objectName)
+		return nil, false
+	}
+	if srcBucket.versioning != "" {
+		w.Header().Set("X-Amz-Copy-Source-Version-Id", src.id)
+	}
+	return src, true
+}
+
+func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, b *bucket, objectName string) {
+	src, ok := s.copySource(w, r, b, objectName)
+	if !ok {
+		return
+	}
+	now := s.now().UTC()
+	meta, apiErr, ok := parseObjectMeta(r, b, now)
+	if !ok {
+		writeError(w, apiErr, b.name, objectName)
+		return
+	}
+	if r.Header.Get("X-Amz-Metadata-Directive") != "REPLACE" {
+		meta.header = src.header.Clone()
+	}
+	if r.Header.Get("X-Amz-Tagging-Directive") != "REPLACE" {
+		meta.tags = src.tags
+	}
+
+	v := &version{
+		objectMeta: meta,
+		data:       src.data,
+		etag:       src.etag,
+		modTime:    now,
+	}
+	b.add(objectName, v)
+	setVersionHeader(w, b, v.id)
+	writeXML(w, http.StatusOK, copyObjectResult{
+		ETag:         "\"" + v.etag + "\"",
+		LastModified: v.modTime,
+	})
+}
+
+// deleteObject removes objectName or one of its versions.
+func (s *Server) deleteObject(r *http.Request, b *bucket, objectName, versionID string) (deletedObject, apiError, bool) {
+	deleted := deletedObject{Key: objectName, VersionID: versionID}
+	if versionID != "" {
+		for _, v := range b.objects[objectName] {
+			if v.id != versionID {
+				continue
+			}
+			if apiErr, locked := v.locked(r, s.now()); locked {
+				return deleted, apiErr, false
+			}
+			b.remove(objectName, versionID)
+			if v.deleteMarker {
+				deleted.DeleteMarker = true
+				deleted.DeleteMarkerVersionID = versionID
+			}
+			break
+		}
+		// Deleting a non-existent version succeeds.
+		return deleted, apiError{}, true
+	}
+
+	if b.versioning == "" {
+		b.remove(objectName, nullVersionID)
+		return deleted, apiError{}, true
+	}
+	marker := &version{deleteMarker: true, modTime: s.now().UTC()}
+	b.add(objectName, marker)
+	deleted.DeleteMarker = true
+	deleted.DeleteMarkerVersionID = marker.id
+	return deleted, apiError{}, true
+}
+
+func (s *Server) removeObject(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	deleted, apiErr, ok := s.deleteObject(r, b, objectName, query.Get("versionId"))
+	if !ok {
+		writeError(w, apiErr, b.name, objectName)
+		return
+	}
+	if deleted.DeleteMarker {
+		w.Header().Set("X-Amz-Delete-Marker", "true")
+		setVersionHeader(w, b, deleted.DeleteMarkerVersionID)
+	} else if deleted.VersionID != "" {
+		setVersionHeader(w, b, deleted.VersionID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) removeObjects(w http.ResponseWriter, r *http.Request, b *bucket) {
+	var req deleteRequest
+	if err := decodeXML(r, &req); err != nil || len(req.Objects) > 1000 {
+		writeError(w, errMalformedXML, b.name, "")
+		return
+	}
+	var result deleteResult
+	for _, obj := range req.Objects {
+		deleted, apiErr, ok := s.deleteObject(r, b, obj.Key, obj.VersionID)
+		if !ok {
+			result.Errors = append(result.Errors, deleteError{
+				Key:       obj.Key,
+				VersionID: obj.VersionID,
+				Code:      apiErr.Code,
+				Message:   apiErr.Message,
+			})
+			continue
+		}
+		if !req.Quiet {
+			result.Deleted = append(result.Deleted, deleted)
+		}
+	}
+	writeXML(w, http.StatusOK, result)
+}
+
+// lookupVersion returns the version of objectName addressed by the
+// versionId query parameter or writes the error response.
+func lookupVersion(w http.ResponseWriter, b *bucket, objectName string, query url.Values) (*version, bool) {
+	v, apiErr, ok := b.lookup(objectName, query.Get("versionId"))
+	if !ok {
+		writeError(w, apiErr, b.name, objectName)
+		return nil, false
+	}
+	setVersionHeader(w, b, v.id)
+	return v, true
+}
+
+func (s *Server) getObjectTagging(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	t := v.tags
+	if t == nil {
+		t, _ = tags.NewTags(nil, true)
+	}
+	writeXML(w, http.StatusOK, t)
+}
+
+func (s *Server) putObjectTagging(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, errMalformedXML, b.name, objectName)
+		return
+	}
+	t, err := tags.ParseObjectXML(bytes.NewReader(data))
+	if err != nil {
+		writeError(w, errInvalidArgument, b.name, objectName)
+		return
+	}
+	v.tags = t
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteObjectTagging(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	v.tags = nil
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getObjectRetention(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	if !b.objectLock {
+		writeError(w, errInvalidRequest, b.name, objectName)
+		return
+	}
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	if v.retentionMode == "" {
+		writeError(w, errNoSuchObjectLockConfig, b.name, objectName)
+		return
+	}
+	writeXML(w, http.StatusOK, retention{
+		Mode:            v.retentionMode,
+		RetainUntilDate: &v.retainUntil,
+	})
+}
+
+func (s *Server) putObjectRetention(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	if !b.objectLock {
+		writeError(w, errInvalidRequest, b.name, objectName)
+		return
+	}
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	var config retention
+	if err := decodeXML(r, &config); err != nil {
+		writeError(w, errMalformedXML, b.name, objectName)
+		return
+	}
+	now := s.now()
+	var until time.Time
+	if config.Mode != "" || config.RetainUntilDate != nil {
+		if !validRetentionMode(config.Mode) || config.RetainUntilDate == nil || !config.RetainUntilDate.After(now) {
+			writeError(w, errInvalidArgument, b.name, objectName)
+			return
+		}
+		until = *config.RetainUntilDate
+	}
+
+	// Active retention may only be extended unless governance mode is
+	// bypassed.
+	if now.Before(v.retainUntil) && (until.Before(v.retainUntil) || config.Mode != v.retentionMode) {
+		bypass := strings.EqualFold(r.Header.Get("X-Amz-Bypass-Governance-Retention"), "true")
+		if v.retentionMode == "COMPLIANCE" {
+			writeError(w, errInvalidRetentionPeriodShort, b.name, objectName)
+			return
+		}
+		if !bypass {
+			writeError(w, errObjectLockedByRetention, b.name, objectName)
+			return
+		}
+	}
+	v.retentionMode = config.Mode
+	v.retainUntil = until
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getObjectLegalHold(w http.ResponseWriter, b *bucket, objectName string, query url.Values) {
+	if !b.objectLock {
+		writeError(w, errInvalidRequest, b.name, objectName)
+		return
+	}
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	writeXML(w, http.StatusOK, legalHold{Status: legalHoldStatus(v.legalHold)})
+}
+
+func (s *Server) putObjectLegalHold(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	if !b.objectLock {
+		writeError(w, errInvalidRequest, b.name, objectName)
+		return
+	}
+	v, ok := lookupVersion(w, b, objectName, query)
+	if !ok {
+		return
+	}
+	var config legalHold
+	if err := decodeXML(r, &config); err != nil || (config.Status != "ON" && config.Status != "OFF") {
+		writeError(w, errMalformedXML, b.name, objectName)
+		return
+	}
+	v.legalHold = config.Status == "ON"
+	w.WriteHeader(http.StatusOK)
+}
+
+// legalHoldStatus returns the legal hold status string.
+func legalHoldStatus(on bool) string {
+	if on {
+		return "ON"
+	}
+	return "OFF"
+}
@@ -0,0 +1,390 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// redacted replaces secrets in recorded exchanges.
+const redacted = "**REDACTED**"
+
+// redactedHeaders are never written to recordings.
+var redactedHeaders = []string{
+	"Authorization",
+	"X-Amz-Security-Token",
+	"X-Amz-S3session-Token",
+	"X-Amz-Server-Side-Encryption-Customer-Key",
+	"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+}
+
+// redactedQueries are never written to recordings, they appear in
+// presigned URLs.
+var redactedQueries = []string{
+	"X-Amz-Signature",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+}
+
+// redactedResponseHeaders are never written to recordings, in addition
+// to the redacted headers.
+var redactedResponseHeaders = []string{
+	"Set-Cookie",
+}
+
+// redactedBodyPatterns match the credentials in response bodies, such as
+// STS, CreateSession and instance metadata responses, and their
+// replacement.
+var redactedBodyPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`<(AccessKeyId|SecretAccessKey|SessionToken)>[^<]*</`), "<$1>" + redacted + "</"},
+	{regexp.MustCompile(`(?i)"(AccessKeyId|SecretAccessKey|SessionToken|Token|AccessKey|SecretKey)"(\s*):(\s*)"(?:[^"\\]|\\.)*"`), `"$1"$2:$3"` + redacted + `"`},
+}
+
+// ignoredHeaders change with every signature and are not matched on
+// replay, in addition to the redacted headers.
+var ignoredHeaders = []string{
+	"Date",
+	"X-Amz-Date",
+	"User-Agent",
+}
+
+// recordedRequest is a request in a recording.
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+
+	// BodySHA256 is the hex encoded SHA-256 of the payload, aws-chunked
+	// bodies are hashed without their chunk signatures.
+	BodySHA256 string `json:"bodySha256"`
+}
+
+// recordedResponse is a response in a recording.
+type recordedResponse struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// exchange is one line of a recording.
+type exchange struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// Recorder is an http.RoundTripper recording all exchanges as JSON lines,
+// which can be replayed with a Replayer. Credentials, signatures and SSE-C
+// keys are redacted from the recording, including credentials returned in
+// response bodies.
+//
+//	f, err := os.Create("testdata/list.jsonl")
+//	...
+//	client, err := minio.New("play.min.io", &minio.Options{
+//	    Creds:     credentials.NewStaticV4("access", "secret", ""),
+//	    Secure:    true,
+//	    Transport: s3test.NewRecorder(f, nil),
+//	})
+type Recorder struct {
+	base http.RoundTripper
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder returns a Recorder sending requests with base and writing
+// the exchanges to w, http.DefaultTransport is used if base is nil.
+func NewRecorder(w io.Writer, base http.RoundTripper) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Recorder{base: base, enc: enc}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rec *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	recReq, err := newRecordedRequest(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rec.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	recResp := newRecordedResponse(resp, respBody)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if err = rec.enc.Encode(exchange{Request: recReq, Response: recResp}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper answering requests with the responses
+// of a recording made by a Recorder, without network access. Requests
+// match a recorded exchange on method, URL, headers and payload, ignoring
+// signatures, credentials and dates. Each recorded exchange is replayed
+// once, in recording order, so that repeated requests such as paginated
+// listings receive their recorded sequence of responses.
+//
+// Requests without a matching exchange fail, clients replaying a
+// recording should set Options.MaxRetries to 1 to fail fast.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges []*exchange
+}
+
+// NewReplayer returns a Replayer for the recording read from r.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	var exchanges []*exchange
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		e := new(exchange)
+		err := dec.Decode(e)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("s3test: invalid recording: %w", err)
+		}
+		exchanges = append(exchanges, e)
+	}
+	return &Replayer{exchanges: exchanges}, nil
+}
+
+// Remaining returns the number of recorded exchanges not replayed yet.
+func (rp *Replayer) Remaining() int {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	return len(rp.exchanges)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rp *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recReq, err := newRecordedRequest(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	rp.mu.Lock()
+	var e *exchange
+	for i, candidate := range rp.exchanges {
+		if candidate.Request.matches(recReq) {
+			e = candidate
+			rp.exchanges = append(rp.exchanges[:i:i], rp.exchanges[i+1:]...)
+			break
+		}
+	}
+	rp.mu.Unlock()
+	if e == nil {
+		return nil, fmt.Errorf("s3test: no recorded exchange for %s %s", req.Method, recReq.URL)
+	}
+
+	respBody := []byte(e.Response.Body)
+	if e.Response.BodyEncoding == "base64" {
+		if respBody, err = base64.StdEncoding.DecodeString(e.Response.Body); err != nil {
+			return nil, err
+		}
+	}
+	header := e.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Response.StatusCode, http.StatusText(e.Response.StatusCode)),
+		StatusCode:    e.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody reads and closes the request body, it returns nil for
+// requests without body.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// newRecordedRequest returns the redacted form of req used for recording
+// and matching.
+func newRecordedRequest(req *http.Request, body []byte) (recordedRequest, error) {
+	payload := body
+	if strings.HasPrefix(req.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		var err error
+		if payload, err = decodeChunked(bytes.NewReader(body)); err != nil {
+			return recordedRequest{}, err
+		}
+	}
+	sum := sha256.Sum256(payload)
+
+	u := *req.URL
+	query := u.Query()
+	for _, k := range redactedQueries {
+		if query.Has(k) {
+			query.Set(k, redacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.User = nil
+
+	header := req.Header.Clone()
+	for _, k := range redactedHeaders {
+		if header.Get(k) != "" {
+			header.Set(k, redacted)
+		}
+	}
+	return recordedRequest{
+		Method:     req.Method,
+		URL:        u.String(),
+		Header:     header,
+		BodySHA256: hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// newRecordedResponse returns the redacted form of resp with body used for
+// recording.
+func newRecordedResponse(resp *http.Response, body []byte) recordedResponse {
+	header := resp.Header.Clone()
+	for _, k := range append(redactedHeaders, redactedResponseHeaders...) {
+		if header.Get(k) != "" {
+			header.Set(k, redacted)
+		}
+	}
+	if !utf8.Valid(body) {
+		return recordedResponse{
+			StatusCode:   resp.StatusCode,
+			Header:       header,
+			Body:         base64.StdEncoding.EncodeToString(body),
+			BodyEncoding: "base64",
+		}
+	}
+	redactedBody := body
+	for _, p := range redactedBodyPatterns {
+		redactedBody = p.re.ReplaceAll(redactedBody, []byte(p.repl))
+	}
+	if !bytes.Equal(redactedBody, body) && header.Get("Content-Length") != "" {
+		header.Set("Content-Length", strconv.Itoa(len(redactedBody)))
+	}
+	return recordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       string(redactedBody),
+	}
+}
+
+// matches returns true if r and other are the same request, ignoring
+// signatures and dates.
+func (r recordedRequest) matches(other recordedRequest) bool {
+	if r.Method != other.Method || r.BodySHA256 != other.BodySHA256 {
+		return false
+	}
+	if normalizeURL(r.URL) != normalizeURL(other.URL) {
+		return false
+	}
+	h1, h2 := normalizeHeader(r.Header), normalizeHeader(other.Header)
+	if len(h1) != len(h2) {
+		return false
+	}
+	for k, v := range h1 {
+		if strings.Join(v, ",") != strings.Join(h2[k], ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeURL drops the presigned query parameters which change with
+// every signature.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for _, k := range append(redactedQueries, "X-Amz-Date") {
+		query.Del(k)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// normalizeHeader drops the headers which change with every signature.
+func normalizeHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header == nil {
+		return http.Header{}
+	}
+	for _, k := range redactedHeaders {
+		header.Del(k)
+	}
+	for _, k := range ignoredHeaders {
+		header.Del(k)
+	}
+	return header
+}
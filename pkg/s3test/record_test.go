@@ -0,0 +1,143 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/s3test"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	srv := s3test.NewServer()
+	endpoint := srv.URL()
+
+	run := func(transport http.RoundTripper) (keys []string, data []byte) {
+		client, err := minio.New(endpoint, &minio.Options{
+			Creds:      credentials.NewStaticV4("AKIATESTRECORDING", "secret", "session-token"),
+			Transport:  transport,
+			MaxRetries: 1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		payload := bytes.Repeat([]byte("z"), 11*1024*1024)
+		_, err = client.PutObject(ctx, "bucket", "large", bytes.NewReader(payload), int64(len(payload)), minio.PutObjectOptions{
+			PartSize: 5 * 1024 * 1024,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"a", "b", "c"} {
+			putString(t, client, "bucket", name, name)
+		}
+		for obj := range client.ListObjects(ctx, "bucket", minio.ListObjectsOptions{MaxKeys: 2}) {
+			if obj.Err != nil {
+				t.Fatal(obj.Err)
+			}
+			keys = append(keys, obj.Key)
+		}
+		return keys, []byte(getString(t, client, "bucket", "large", minio.GetObjectOptions{}))
+	}
+
+	var recording bytes.Buffer
+	wantKeys, wantData := run(s3test.NewRecorder(&recording, nil))
+	srv.Close()
+
+	for _, secret := range []string{"AKIATESTRECORDING", "session-token", "Signature="} {
+		if bytes.Contains(recording.Bytes(), []byte(secret)) {
+			t.Fatalf("recording contains %q", secret)
+		}
+	}
+
+	replayer, err := s3test.NewReplayer(bytes.NewReader(recording.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, data := run(replayer)
+	if !slices.Equal(keys, wantKeys) || !bytes.Equal(data, wantData) {
+		t.Fatalf("replay mismatch, expected %v, got %v", wantKeys, keys)
+	}
+	if n := replayer.Remaining(); n != 0 {
+		t.Fatalf("expected all exchanges to be replayed, %d remaining", n)
+	}
+}
+
+func TestRecordRedactsResponseCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/credentials" {
+			fmt.Fprint(w, `{"Code": "Success", "AccessKeyId" : "ASIAJSON", "SecretAccessKey": "json\"secret", "Token": "json-token"}`)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-secret"})
+		fmt.Fprint(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult><Credentials>`+
+			`<AccessKeyId>ASIARECORDED</AccessKeyId><SecretAccessKey>recorded-secret</SecretAccessKey>`+
+			`<SessionToken>recorded-token</SessionToken><Expiration>2099-01-01T00:00:00Z</Expiration>`+
+			`</Credentials></AssumeRoleResult></AssumeRoleResponse>`)
+	}))
+	defer ts.Close()
+
+	var recording bytes.Buffer
+	sts := &credentials.STSAssumeRole{
+		Client:      &http.Client{Transport: s3test.NewRecorder(&recording, nil)},
+		STSEndpoint: ts.URL,
+		Options:     credentials.STSAssumeRoleOptions{AccessKey: "access", SecretKey: "secret"},
+	}
+	v, err := sts.Retrieve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKeyID != "ASIARECORDED" || v.SecretAccessKey != "recorded-secret" {
+		t.Fatalf("expected the response to reach the client, got %+v", v)
+	}
+	resp, err := sts.Client.Get(ts.URL + "/credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	for _, secret := range []string{"ASIARECORDED", "recorded-secret", "recorded-token", "cookie-secret", "ASIAJSON", `json\\`, "json-token"} {
+		if bytes.Contains(recording.Bytes(), []byte(secret)) {
+			t.Fatalf("recording contains %q", secret)
+		}
+	}
+
+	// The redacted response is still a valid STS response.
+	replayer, err := s3test.NewReplayer(bytes.NewReader(recording.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sts.Client = &http.Client{Transport: replayer}
+	if v, err = sts.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKeyID != "**REDACTED**" || v.Expiration.Year() != 2099 {
+		t.Fatalf("unexpected replayed credentials %+v", v)
+	}
+}
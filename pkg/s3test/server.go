@@ -0,0 +1,333 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s3test provides an in-memory S3 compatible server for hermetic
+// unit tests. It covers buckets, PutObject and multipart uploads, ranged
+// and conditional GetObject, ListObjects V1/V2 and object versions,
+// RemoveObject(s), tagging, versioning and object lock, speaking the same
+// XML as Amazon S3. Requests are not authenticated, any credentials are
+// accepted.
+//
+//	srv := s3test.NewServer()
+//	defer srv.Close()
+//
+//	client, err := minio.New(srv.URL(), &minio.Options{
+//	    Creds: credentials.NewStaticV4("access", "secret", ""),
+//	})
+//
+// Recorder and Replayer capture the exchanges of a client with a real
+// server once and replay them offline.
+package s3test
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Server is an in-memory S3 compatible server.
+type Server struct {
+	srv *httptest.Server
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewServer starts and returns a new Server, the caller should call Close
+// when finished to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the endpoint of the server in the host:port form expected
+// by minio.New.
+func (s *Server) URL() string {
+	return s.srv.Listener.Addr().String()
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// SetClock replaces the clock used for modification times and retention,
+// useful to create deterministic object versions.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// ServeHTTP implements http.Handler, dispatching to the S3 API handlers
+// based on the method, path and sub-resource.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucketName, objectName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+
+	if bucketName == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, errNotImplemented, "", "")
+			return
+		}
+		s.listBuckets(w)
+		return
+	}
+
+	if objectName == "" {
+		s.serveBucket(w, r, bucketName, query)
+		return
+	}
+
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		writeError(w, errNoSuchBucket, bucketName, "")
+		return
+	}
+	s.serveObject(w, r, b, objectName, query)
+}
+
+func (s *Server) serveBucket(w http.ResponseWriter, r *http.Request, bucketName string, query url.Values) {
+	if r.Method == http.MethodPut && len(query) == 0 {
+		s.makeBucket(w, r, bucketName)
+		return
+	}
+	b, ok := s.buckets[bucketName]
+	if !ok {
+		writeError(w, errNoSuchBucket, bucketName, "")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		switch {
+		case query.Has("location"):
+			writeXML(w, http.StatusOK, locationConstraint{})
+		case query.Has("versioning"):
+			writeXML(w, http.StatusOK, versioningConfiguration{Status: b.versioning})
+		case query.Has("object-lock"):
+			s.getObjectLockConfig(w, b)
+		case query.Has("tagging"):
+			s.getBucketTagging(w, b)
+		case query.Has("uploads"):
+			s.listMultipartUploads(w, b, query)
+		case query.Has("versions"):
+			s.listObjectVersions(w, b, query)
+		case query.Get("list-type") == "2":
+			s.listObjectsV2(w, b, query)
+		case hasSubResource(query):
+			writeError(w, errNotImplemented, b.name, "")
+		default:
+			s.listObjectsV1(w, b, query)
+		}
+	case http.MethodPut:
+		switch {
+		case query.Has("versioning"):
+			s.putBucketVersioning(w, r, b)
+		case query.Has("object-lock"):
+			s.putObjectLockConfig(w, r, b)
+		case query.Has("tagging"):
+			s.putBucketTagging(w, r, b)
+		default:
+			writeError(w, errNotImplemented, b.name, "")
+		}
+	case http.MethodPost:
+		if query.Has("delete") {
+			s.removeObjects(w, r, b)
+			return
+		}
+		writeError(w, errNotImplemented, b.name, "")
+	case http.MethodDelete:
+		switch {
+		case query.Has("tagging"):
+			b.tags = nil
+			w.WriteHeader(http.StatusNoContent)
+		case len(query) == 0:
+			s.removeBucket(w, b)
+		default:
+			writeError(w, errNotImplemented, b.name, "")
+		}
+	default:
+		writeError(w, errNotImplemented, b.name, "")
+	}
+}
+
+func (s *Server) serveObject(w http.ResponseWriter, r *http.Request, b *bucket, objectName string, query url.Values) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		switch {
+		case query.Has("uploadId"):
+			s.listObjectParts(w, b, objectName, query)
+		case query.Has("tagging"):
+			s.getObjectTagging(w, b, objectName, query)
+		case query.Has("retention"):
+			s.getObjectRetention(w, b, objectName, query)
+		case query.Has("legal-hold"):
+			s.getObjectLegalHold(w, b, objectName, query)
+		case hasSubResource(query):
+			writeError(w, errNotImplemented, b.name, objectName)
+		default:
+			s.getObject(w, r, b, objectName, query)
+		}
+	case http.MethodPut:
+		switch {
+		case query.Has("uploadId"):
+			s.putObjectPart(w, r, b, objectName, query)
+		case query.Has("tagging"):
+			s.putObjectTagging(w, r, b, objectName, query)
+		case query.Has("retention"):
+			s.putObjectRetention(w, r, b, objectName, query)
+		case query.Has("legal-hold"):
+			s.putObjectLegalHold(w, r, b, objectName, query)
+		case hasSubResource(query):
+			writeError(w, errNotImplemented, b.name, objectName)
+		case r.Header.Get("X-Amz-Copy-Source") != "":
+			s.copyObject(w, r, b, objectName)
+		default:
+			s.putObject(w, r, b, objectName)
+		}
+	case http.MethodPost:
+		switch {
+		case query.Has("uploads"):
+			s.newMultipartUpload(w, r, b, objectName)
+		case query.Has("uploadId"):
+			s.completeMultipartUpload(w, r, b, objectName, query)
+		default:
+			writeError(w, errNotImplemented, b.name, objectName)
+		}
+	case http.MethodDelete:
+		switch {
+		case query.Has("uploadId"):
+			s.abortMultipartUpload(w, b, objectName, query)
+		case query.Has("tagging"):
+			s.deleteObjectTagging(w, b, objectName, query)
+		default:
+			s.removeObject(w, r, b, objectName, query)
+		}
+	default:
+		writeError(w, errNotImplemented, b.name, objectName)
+	}
+}
+
+// queries which are parameters rather than sub-resources.
+var parameterQueries = map[string]bool{
+	"versionId": true, "partNumber": true, "prefix": true, "delimiter": true,
+	"marker": true, "max-keys": true, "encoding-type": true, "continuation-token": true,
+	"start-after": true, "fetch-owner": true, "list-type": true, "metadata": true,
+	"key-marker": true, "version-id-marker": true, "upload-id-marker": true,
+	"max-uploads": true, "max-parts": true, "part-number-marker": true,
+}
+
+// hasSubResource returns true for requests on sub-resources not
+// implemented by the server.
+func hasSubResource(query url.Values) bool {
+	for k := range query {
+		if !parameterQueries[k] && !strings.HasPrefix(k, "response-") && !strings.HasPrefix(k, "X-Amz-") {
+			return true
+		}
+	}
+	return false
+}
+
+// newVersionID returns a new unique version ID.
+func newVersionID() string {
+	return uuid.NewString()
+}
+
+// readBody reads the request body, decoding aws-chunked payloads sent
+// with streaming signatures or unsigned trailers.
+func readBody(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	return decodeChunked(r.Body)
+}
+
+// decodeChunked returns the payload of an aws-chunked encoded body,
+// dropping the chunk signatures and trailers.
+func decodeChunked(body io.Reader) ([]byte, error) {
+	var data []byte
+	br := bufio.NewReader(body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeStr, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeStr, 16, 64)
+		if err != nil {
+			return nil, errors.New("malformed chunk header")
+		}
+		if size == 0 {
+			// Trailing headers are not verified.
+			io.Copy(io.Discard, br)
+			return data, nil
+		}
+		chunk := make([]byte, size)
+		if _, err = io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+		if _, err = br.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// decodeXML decodes the request body into v.
+func decodeXML(r *http.Request, v any) error {
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, v)
+}
+
+// writeXML writes v as XML response with the status code.
+func writeXML(w http.ResponseWriter, statusCode int, v any) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Length", strconv.Itoa(len(xml.Header)+len(data)))
+	w.WriteHeader(statusCode)
+	io.WriteString(w, xml.Header)
+	w.Write(data)
+}
@@ -0,0 +1,309 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3test_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/s3test"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+func newTestClient(t *testing.T) *minio.Client {
+	t.Helper()
+	srv := s3test.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := minio.New(srv.URL(), &minio.Options{
+		Creds: credentials.NewStaticV4("access", "secret", ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func putString(t *testing.T, client *minio.Client, bucketName, objectName, data string) minio.UploadInfo {
+	t.Helper()
+	info, err := client.PutObject(context.Background(), bucketName, objectName, strings.NewReader(data), int64(len(data)), minio.PutObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func getString(t *testing.T, client *minio.Client, bucketName, objectName string, opts minio.GetObjectOptions) string {
+	t.Helper()
+	obj, err := client.GetObject(context.Background(), bucketName, objectName, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestServerObjects(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); minio.ToErrorResponse(err).Code != minio.BucketAlreadyOwnedByYou {
+		t.Fatalf("expected BucketAlreadyOwnedByYou, got %v", err)
+	}
+
+	_, err := client.PutObject(ctx, "bucket", "a/b.txt", strings.NewReader("hello world"), 11, minio.PutObjectOptions{
+		ContentType:  "text/plain",
+		UserMetadata: map[string]string{"Color": "blue"},
+		UserTags:     map[string]string{"k": "v"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	putString(t, client, "bucket", "a/c.txt", "c")
+	putString(t, client, "bucket", "d.txt", "d")
+
+	info, err := client.StatObject(ctx, "bucket", "a/b.txt", minio.StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != 11 || info.ContentType != "text/plain" || info.UserMetadata["Color"] != "blue" || info.UserTagCount != 1 {
+		t.Fatalf("unexpected object info %+v", info)
+	}
+
+	var opts minio.GetObjectOptions
+	opts.SetRange(6, 10)
+	if got := getString(t, client, "bucket", "a/b.txt", opts); got != "world" {
+		t.Fatalf("expected range to return world, got %q", got)
+	}
+	opts = minio.GetObjectOptions{}
+	opts.SetMatchETagExcept(info.ETag)
+	if _, err = client.StatObject(ctx, "bucket", "a/b.txt", opts); minio.ToErrorResponse(err).StatusCode != 304 {
+		t.Fatalf("expected not modified, got %v", err)
+	}
+	opts = minio.GetObjectOptions{}
+	opts.SetMatchETag("other")
+	if _, err = client.StatObject(ctx, "bucket", "a/b.txt", opts); minio.ToErrorResponse(err).Code != minio.PreconditionFailed {
+		t.Fatalf("expected precondition failed, got %v", err)
+	}
+
+	otags, err := client.GetObjectTagging(ctx, "bucket", "a/b.txt", minio.GetObjectTaggingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otags.ToMap()["k"] != "v" {
+		t.Fatalf("unexpected tags %v", otags)
+	}
+
+	_, err = client.CopyObject(ctx, minio.CopyDestOptions{Bucket: "bucket", Object: "e.txt"}, minio.CopySrcOptions{Bucket: "bucket", Object: "d.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getString(t, client, "bucket", "e.txt", minio.GetObjectOptions{}); got != "d" {
+		t.Fatalf("expected copied content, got %q", got)
+	}
+
+	for _, useV1 := range []bool{false, true} {
+		var keys []string
+		for obj := range client.ListObjects(ctx, "bucket", minio.ListObjectsOptions{UseV1: useV1, MaxKeys: 1}) {
+			if obj.Err != nil {
+				t.Fatal(obj.Err)
+			}
+			keys = append(keys, obj.Key)
+		}
+		if want := []string{"a/", "d.txt", "e.txt"}; !slices.Equal(keys, want) {
+			t.Fatalf("V1 %v: expected %v, got %v", useV1, want, keys)
+		}
+	}
+
+	objectsCh := make(chan minio.ObjectInfo, 2)
+	objectsCh <- minio.ObjectInfo{Key: "d.txt"}
+	objectsCh <- minio.ObjectInfo{Key: "e.txt"}
+	close(objectsCh)
+	for rerr := range client.RemoveObjects(ctx, "bucket", objectsCh, minio.RemoveObjectsOptions{}) {
+		t.Fatal(rerr.Err)
+	}
+	if _, err = client.StatObject(ctx, "bucket", "d.txt", minio.StatObjectOptions{}); minio.ToErrorResponse(err).Code != minio.NoSuchKey {
+		t.Fatalf("expected NoSuchKey, got %v", err)
+	}
+	if err = client.RemoveBucket(ctx, "bucket"); minio.ToErrorResponse(err).Code != "BucketNotEmpty" {
+		t.Fatalf("expected BucketNotEmpty, got %v", err)
+	}
+}
+
+func TestServerMultipart(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("0123456789abcdef"), 12*1024*1024/16)
+	info, err := client.PutObject(ctx, "bucket", "large", bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		PartSize: 5 * 1024 * 1024,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(info.ETag, "-3") {
+		t.Fatalf("expected multipart ETag, got %s", info.ETag)
+	}
+	if got := getString(t, client, "bucket", "large", minio.GetObjectOptions{}); got != string(data) {
+		t.Fatal("multipart object content mismatch")
+	}
+
+	core := minio.Core{Client: client}
+	uploadID, err := core.NewMultipartUpload(ctx, "bucket", "small", minio.PutObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []minio.CompletePart
+	for i := 1; i <= 2; i++ {
+		part, err := core.PutObjectPart(ctx, "bucket", "small", uploadID, i, strings.NewReader("part"), 4, minio.PutObjectPartOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, minio.CompletePart{PartNumber: i, ETag: part.ETag})
+	}
+	if _, err = core.CompleteMultipartUpload(ctx, "bucket", "small", uploadID, parts, minio.PutObjectOptions{}); minio.ToErrorResponse(err).Code != "EntityTooSmall" {
+		t.Fatalf("expected EntityTooSmall, got %v", err)
+	}
+	var uploads int
+	for upload := range client.ListIncompleteUploads(ctx, "bucket", "", true) {
+		if upload.Err != nil {
+			t.Fatal(upload.Err)
+		}
+		uploads++
+	}
+	if uploads != 1 {
+		t.Fatalf("expected 1 incomplete upload, got %d", uploads)
+	}
+	if err = core.AbortMultipartUpload(ctx, "bucket", "small", uploadID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerVersioning(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.EnableVersioning(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	v1 := putString(t, client, "bucket", "obj", "v1")
+	putString(t, client, "bucket", "obj", "v2")
+	if err := client.RemoveObject(ctx, "bucket", "obj", minio.RemoveObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := getString(t, client, "bucket", "obj", minio.GetObjectOptions{VersionID: v1.VersionID}); got != "v1" {
+		t.Fatalf("expected v1, got %q", got)
+	}
+	if _, err := client.StatObject(ctx, "bucket", "obj", minio.StatObjectOptions{}); minio.ToErrorResponse(err).Code != minio.NoSuchKey {
+		t.Fatalf("expected NoSuchKey, got %v", err)
+	}
+
+	var kinds []string
+	for obj := range client.ListObjects(ctx, "bucket", minio.ListObjectsOptions{WithVersions: true, MaxKeys: 2}) {
+		if obj.Err != nil {
+			t.Fatal(obj.Err)
+		}
+		kind := "version"
+		if obj.IsDeleteMarker {
+			kind = "marker"
+		}
+		kinds = append(kinds, kind)
+	}
+	if want := []string{"marker", "version", "version"}; !slices.Equal(kinds, want) {
+		t.Fatalf("expected %v, got %v", want, kinds)
+	}
+}
+
+func TestServerObjectLock(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if err := client.MakeBucket(ctx, "bucket", minio.MakeBucketOptions{ObjectLocking: true}); err != nil {
+		t.Fatal(err)
+	}
+	mode := minio.Governance
+	validity := uint(1)
+	unit := minio.Days
+	if err := client.SetObjectLockConfig(ctx, "bucket", &mode, &validity, &unit); err != nil {
+		t.Fatal(err)
+	}
+	info := putString(t, client, "bucket", "obj", "data")
+
+	gotMode, until, err := client.GetObjectRetention(ctx, "bucket", "obj", info.VersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *gotMode != minio.Governance || until.Before(time.Now()) {
+		t.Fatalf("unexpected default retention %v %v", *gotMode, until)
+	}
+	err = client.RemoveObject(ctx, "bucket", "obj", minio.RemoveObjectOptions{VersionID: info.VersionID})
+	if minio.ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("expected AccessDenied, got %v", err)
+	}
+
+	status := minio.LegalHoldEnabled
+	if err = client.PutObjectLegalHold(ctx, "bucket", "obj", minio.PutObjectLegalHoldOptions{VersionID: info.VersionID, Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+	err = client.RemoveObject(ctx, "bucket", "obj", minio.RemoveObjectOptions{VersionID: info.VersionID, GovernanceBypass: true})
+	if minio.ToErrorResponse(err).Code != "AccessDenied" {
+		t.Fatalf("expected legal hold to deny delete, got %v", err)
+	}
+	status = minio.LegalHoldDisabled
+	if err = client.PutObjectLegalHold(ctx, "bucket", "obj", minio.PutObjectLegalHoldOptions{VersionID: info.VersionID, Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.RemoveObject(ctx, "bucket", "obj", minio.RemoveObjectOptions{VersionID: info.VersionID, GovernanceBypass: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	btags, err := tags.NewTags(map[string]string{"team": "storage"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.SetBucketTagging(ctx, "bucket", btags); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.GetBucketTagging(ctx, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ToMap()["team"] != "storage" {
+		t.Fatalf("unexpected bucket tags %v", got)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	md5simd "github.com/minio/md5-simd"
+)
+
+// Scope is the credential scope of a signature v4 signature.
+type Scope struct {
+	Time        time.Time
+	Region      string
+	ServiceType string
+}
+
+// String returns the scope as <date>/<region>/<service>/aws4_request.
+func (s Scope) String() string {
+	return getScope(s.Region, s.Time, s.ServiceType)
+}
+
+// Signer computes signature v4 signatures for an access key whose secret
+// key is not available to the process, such as a key held in an HSM or
+// by a signing sidecar.
+type Signer interface {
+	// Sign returns the hex encoded signature of stringToSign,
+	// HMAC-SHA256 keyed with the signing key of scope.
+	Sign(ctx context.Context, scope Scope, stringToSign string) (string, error)
+}
+
+// SigningKeyDeriver is implemented by Signers which derive the signing key
+// of a scope remotely, see DeriveSigningKey. The signing key is only valid
+// for the day, region and service of the scope, it is cached and used to
+// sign locally rather than calling Sign for every signature, such as
+// the chunk signatures of streaming uploads.
+type SigningKeyDeriver interface {
+	SigningKey(ctx context.Context, scope Scope) ([]byte, error)
+}
+
+// DeriveSigningKey returns the signing key of scope derived from the
+// secret key, for implementing a Signer where the secret key is held.
+func DeriveSigningKey(secretAccessKey string, scope Scope) []byte {
+	return getSigningKey(secretAccessKey, scope.Region, scope.Time, scope.ServiceType)
+}
+
+// signFunc returns the signature of stringToSign in scope.
+type signFunc func(scope Scope, stringToSign string) (string, error)
+
+// secretKeySign returns a signFunc signing with the secret key.
+func secretKeySign(secretAccessKey string) signFunc {
+	return func(scope Scope, stringToSign string) (string, error) {
+		return getSignature(DeriveSigningKey(secretAccessKey, scope), stringToSign), nil
+	}
+}
+
+// externalSign returns a signFunc signing with s, signing keys of a
+// SigningKeyDeriver are cached for their scope.
+func externalSign(ctx context.Context, s Signer) signFunc {
+	d, ok := s.(SigningKeyDeriver)
+	if !ok {
+		return func(scope Scope, stringToSign string) (string, error) {
+			return s.Sign(ctx, scope, stringToSign)
+		}
+	}
+	var (
+		signingKey []byte
+		keyScope   string
+	)
+	return func(scope Scope, stringToSign string) (string, error) {
+		if signingKey == nil || keyScope != scope.String() {
+			key, err := d.SigningKey(ctx, scope)
+			if err != nil {
+				return "", err
+			}
+			signingKey, keyScope = key, scope.String()
+		}
+		return getSignature(signingKey, stringToSign), nil
+	}
+}
+
+// SignV4WithSigner is like SignV4Trailer but signs with s for the service
+// type, instead of a secret key.
+func SignV4WithSigner(ctx context.Context, req http.Request, s Signer, accessKeyID, sessionToken, location, serviceType string, trailer http.Header) (*http.Request, error) {
+	return signV4With(req, accessKeyID, sessionToken, location, serviceType, trailer, externalSign(ctx, s))
+}
+
+// PreSignV4WithSigner is like PreSignV4 but signs with s for the service
+// type, instead of a secret key.
+func PreSignV4WithSigner(ctx context.Context, req http.Request, s Signer, accessKeyID, sessionToken, location, serviceType string, expires int64) (*http.Request, error) {
+	return preSignV4(req, accessKeyID, sessionToken, location, serviceType, expires, externalSign(ctx, s))
+}
+
+// PostPresignSignatureV4WithSigner is like PostPresignSignatureV4 but signs
+// with s, instead of a secret key.
+func PostPresignSignatureV4WithSigner(ctx context.Context, policyBase64 string, t time.Time, s Signer, location string) (string, error) {
+	return externalSign(ctx, s)(Scope{Time: t, Region: location, ServiceType: ServiceTypeS3}, policyBase64)
+}
+
+// StreamingSignV4WithSigner is like StreamingSignV4 but signs with s for
+// the service type, instead of a secret key. Chunks are signed with ctx
+// while the request body is read, errors of s are returned by reads.
+func StreamingSignV4WithSigner(ctx context.Context, req *http.Request, s Signer, accessKeyID, sessionToken,
+	region, serviceType string, dataLen int64, reqTime time.Time, sh256 md5simd.Hasher,
+) (*http.Request, error) {
+	// Set headers needed for streaming signature.
+	prepareStreamingRequest(req, sessionToken, dataLen, reqTime)
+
+	if req.Body == nil {
+		req.Body = io.NopCloser(bytes.NewReader([]byte("")))
+	}
+
+	stReader := &StreamingReader{
+		baseReadCloser: req.Body,
+		accessKeyID:    accessKeyID,
+		sessionToken:   sessionToken,
+		region:         region,
+		serviceType:    serviceType,
+		reqTime:        reqTime,
+		chunkBuf:       make([]byte, payloadChunkSize),
+		contentLen:     dataLen,
+		chunkNum:       1,
+		totalChunks:    int((dataLen+payloadChunkSize-1)/payloadChunkSize) + 1,
+		lastChunkSize:  int(dataLen % payloadChunkSize),
+		sh256:          sh256,
+		sign:           externalSign(ctx, s),
+	}
+	if len(req.Trailer) > 0 {
+		stReader.trailer = req.Trailer
+		req.Trailer = nil
+	}
+
+	// Compute the seed signature.
+	canonicalRequest := getCanonicalRequest(*req, ignoredStreamingHeaders, getHashedPayload(*req))
+	stringToSign := getStringToSignV4(reqTime, region, canonicalRequest, serviceType)
+	seedSignature, err := stReader.sign(Scope{Time: reqTime, Region: region, ServiceType: serviceType}, stringToSign)
+	if err != nil {
+		return nil, err
+	}
+	stReader.seedSignature = seedSignature
+
+	// Set the authorization header with the seed signature.
+	stReader.setStreamingAuthHeader(req, serviceType)
+
+	stReader.prevSignature = stReader.seedSignature
+	req.Body = stReader
+	return req, nil
+}
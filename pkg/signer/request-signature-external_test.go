@@ -0,0 +1,130 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testSigner signs with the secret key, like a signing sidecar.
+type testSigner struct {
+	err   error
+	signs int
+}
+
+func (s *testSigner) Sign(_ context.Context, scope Scope, stringToSign string) (string, error) {
+	s.signs++
+	if s.err != nil {
+		return "", s.err
+	}
+	return getSignature(DeriveSigningKey(verifySecretKey, scope), stringToSign), nil
+}
+
+// testKeySigner derives signing keys with the secret key.
+type testKeySigner struct {
+	testSigner
+	keys int
+}
+
+func (s *testKeySigner) SigningKey(_ context.Context, scope Scope) ([]byte, error) {
+	s.keys++
+	return DeriveSigningKey(verifySecretKey, scope), nil
+}
+
+func TestSignV4WithSigner(t *testing.T) {
+	ctx := context.Background()
+	s := &testSigner{}
+	req := newVerifyRequest(http.MethodPut, "http://s3.example.com/bucket/object?tagging", "payload")
+	signed, err := SignV4WithSigner(ctx, *req, s, verifyAccessKey, "token", "us-east-1", ServiceTypeS3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = VerifyV4(toServerRequest(t, signed), verifyLookup, VerifyOptions{Region: "us-east-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "http://s3.example.com/bucket/object", nil)
+	signed, err = PreSignV4WithSigner(ctx, *req, s, verifyAccessKey, "", "us-east-1", ServiceTypeS3, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = VerifyPresignedV4(toServerRequest(t, signed), verifyLookup, VerifyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if s.signs != 2 {
+		t.Fatalf("expected 2 signatures, got %d", s.signs)
+	}
+
+	s.err = errors.New("signer unavailable")
+	if _, err = SignV4WithSigner(ctx, *req, s, verifyAccessKey, "", "us-east-1", ServiceTypeS3, nil); !errors.Is(err, s.err) {
+		t.Fatalf("expected %v, got %v", s.err, err)
+	}
+}
+
+func TestStreamingSignV4WithSigner(t *testing.T) {
+	reqTime, _ := time.Parse(iso8601DateFormat, "20130524T000000Z")
+	opts := VerifyOptions{Now: func() time.Time { return reqTime }}
+	data := bytes.Repeat([]byte("a"), 3*payloadChunkSize)
+
+	sign := func(s Signer) (*http.Request, error) {
+		req, _ := http.NewRequest(http.MethodPut, "http://s3.example.com/bucket/object", bytes.NewReader(data))
+		req.Trailer = http.Header{"X-Amz-Checksum-Crc32c": {"wdBDMA=="}}
+		return StreamingSignV4WithSigner(context.Background(), req, s, verifyAccessKey, "", "us-east-1",
+			ServiceTypeS3, int64(len(data)), reqTime, newSHA256Hasher())
+	}
+
+	// Signing keys are derived once and chunks are signed locally.
+	ks := &testKeySigner{}
+	req, err := sign(ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ks.keys != 1 || ks.signs != 0 {
+		t.Fatalf("expected 1 derived key and no remote signatures, got %d and %d", ks.keys, ks.signs)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(signed))
+	req.TransferEncoding = nil
+	req = toServerRequest(t, req)
+	if _, err = VerifyV4(req, verifyLookup, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(req.Body); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("decoded payload does not match: %v", err)
+	}
+
+	// Signers without signing keys sign every chunk.
+	s := &testSigner{}
+	req, err = sign(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.err = errors.New("signer unavailable")
+	if _, err = io.ReadAll(req.Body); !errors.Is(err, s.err) {
+		t.Fatalf("expected %v, got %v", s.err, err)
+	}
+}
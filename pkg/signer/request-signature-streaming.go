@@ -55,17 +55,18 @@ var ignoredStreamingHeaders = map[string]bool{
 }
 
 // getSignedChunkLength - calculates the length of chunk metadata
-func getSignedChunkLength(chunkDataSize int64) int64 {
+func getSignedChunkLength(chunkDataSize, sigLen int64) int64 {
 	return int64(len(fmt.Sprintf("%x", chunkDataSize))) +
 		chunkSigConstLen +
-		signatureStrLen +
+		sigLen +
 		crlfLen +
 		chunkDataSize +
 		crlfLen
 }
 
 // getStreamLength - calculates the length of the overall stream (data + metadata)
-func getStreamLength(dataLen, chunkSize int64, trailers http.Header) int64 {
+// with signatures of length sigLen.
+func getStreamLength(dataLen, chunkSize, sigLen int64, trailers http.Header) int64 {
 	if dataLen <= 0 {
 		return 0
 	}
@@ -73,18 +74,18 @@ func getStreamLength(dataLen, chunkSize int64, trailers http.Header) int64 {
 	chunksCount := int64(dataLen / chunkSize)
 	remainingBytes := int64(dataLen % chunkSize)
 	streamLen := int64(0)
-	streamLen += chunksCount * getSignedChunkLength(chunkSize)
+	streamLen += chunksCount * getSignedChunkLength(chunkSize, sigLen)
 	if remainingBytes > 0 {
-		streamLen += getSignedChunkLength(remainingBytes)
+		streamLen += getSignedChunkLength(remainingBytes, sigLen)
 	}
-	streamLen += getSignedChunkLength(0)
+	streamLen += getSignedChunkLength(0, sigLen)
 	if len(trailers) > 0 {
 		for name, placeholder := range trailers {
 			if len(placeholder) > 0 {
 				streamLen += int64(len(name) + len(trailerKVSeparator) + len(placeholder[0]) + 1)
 			}
 		}
-		streamLen += int64(len(trailerSignature)+len(trailerKVSeparator)) + signatureStrLen + crlfLen + crlfLen
+		streamLen += int64(len(trailerSignature)+len(trailerKVSeparator)) + sigLen + crlfLen + crlfLen
 	}
 
 	return streamLen
@@ -141,7 +142,7 @@ func prepareStreamingRequest(req *http.Request, sessionToken string, dataLen int
 
 	req.Header.Set("X-Amz-Date", timestamp.Format(iso8601DateFormat))
 	// Set content length with streaming signature for each chunk included.
-	req.ContentLength = getStreamLength(dataLen, int64(payloadChunkSize), req.Trailer)
+	req.ContentLength = getStreamLength(dataLen, int64(payloadChunkSize), signatureStrLen, req.Trailer)
 	req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(dataLen, 10))
 }
 
@@ -214,6 +215,9 @@ type StreamingReader struct {
 	lastChunkSize   int
 	trailer         http.Header
 	sh256           md5simd.Hasher
+	v4a             *v4aSigner // set for signature v4a
+	sign            signFunc   // set for external signers
+	err             error      // error of signing a chunk
 }
 
 // signChunk - signs a chunk read from s.baseReader of chunkLen size.
@@ -223,18 +227,29 @@ func (s *StreamingReader) signChunk(chunkLen int, addCrLf bool) {
 	s.sh256.Write(s.chunkBuf[:chunkLen])
 	chunckChecksum := hex.EncodeToString(s.sh256.Sum(nil))
 
-	serviceType := s.serviceType
-	if serviceType == "" {
-		serviceType = ServiceTypeS3
+	var signature, chunkSignature string
+	if s.v4a != nil {
+		signature, s.err = s.v4a.chunkSignature(s.prevSignature, chunckChecksum)
+		chunkSignature = padV4ASignature(signature)
+	} else if s.sign != nil {
+		signature, s.err = s.sign(Scope{Time: s.reqTime, Region: s.region, ServiceType: s.serviceType},
+			buildChunkStringToSignWithService(s.reqTime, s.region, s.prevSignature, chunckChecksum, s.serviceType))
+		chunkSignature = signature
+	} else {
+		serviceType := s.serviceType
+		if serviceType == "" {
+			serviceType = ServiceTypeS3
+		}
+		signature = buildChunkSignature(chunckChecksum, s.reqTime,
+			s.region, s.prevSignature, s.secretAccessKey, serviceType)
+		chunkSignature = signature
 	}
-	signature := buildChunkSignature(chunckChecksum, s.reqTime,
-		s.region, s.prevSignature, s.secretAccessKey, serviceType)
 
 	// For next chunk signature computation
 	s.prevSignature = signature
 
 	// Write chunk header into streaming buffer
-	chunkHdr := buildChunkHeader(int64(chunkLen), signature)
+	chunkHdr := buildChunkHeader(int64(chunkLen), chunkSignature)
 	s.buf.Write(chunkHdr)
 
 	// Write chunk data into streaming buffer
@@ -262,18 +277,29 @@ func (s *StreamingReader) addSignedTrailer(h http.Header) {
 	s.sh256.Reset()
 	s.sh256.Write(s.chunkBuf)
 	chunkChecksum := hex.EncodeToString(s.sh256.Sum(nil))
-	serviceType := s.serviceType
-	if serviceType == "" {
-		serviceType = ServiceTypeS3
+	var signature, chunkSignature string
+	if s.v4a != nil {
+		signature, s.err = s.v4a.trailerSignature(s.prevSignature, chunkChecksum)
+		chunkSignature = padV4ASignature(signature)
+	} else if s.sign != nil {
+		signature, s.err = s.sign(Scope{Time: s.reqTime, Region: s.region, ServiceType: s.serviceType},
+			buildTrailerChunkStringToSignWithService(s.reqTime, s.region, s.prevSignature, chunkChecksum, s.serviceType))
+		chunkSignature = signature
+	} else {
+		serviceType := s.serviceType
+		if serviceType == "" {
+			serviceType = ServiceTypeS3
+		}
+		signature = buildTrailerChunkSignature(chunkChecksum, s.reqTime,
+			s.region, s.prevSignature, s.secretAccessKey, serviceType)
+		chunkSignature = signature
 	}
-	signature := buildTrailerChunkSignature(chunkChecksum, s.reqTime,
-		s.region, s.prevSignature, s.secretAccessKey, serviceType)
 
 	// For next chunk signature computation
 	s.prevSignature = signature
 
 	s.buf.Write(s.chunkBuf)
-	s.buf.WriteString("\r\n" + trailerSignature + trailerKVSeparator + signature + "\r\n\r\n")
+	s.buf.WriteString("\r\n" + trailerSignature + trailerKVSeparator + chunkSignature + "\r\n\r\n")
 
 	// Reset chunkBufLen for next chunk read.
 	s.chunkBuf = s.chunkBuf[:olen]
@@ -429,6 +455,9 @@ func StreamingSignV4Outposts(req *http.Request, accessKeyID, secretAccessKey, se
 // Read - this method performs chunk upload signature providing a
 // io.Reader interface.
 func (s *StreamingReader) Read(buf []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
 	switch {
 	// After the last chunk is read from underlying reader, we
 	// never re-fill s.buf.
@@ -460,6 +489,9 @@ func (s *StreamingReader) Read(buf []byte) (int, error) {
 						s.chunkBufLen == s.lastChunkSize) {
 					// Sign the chunk and write it to s.buf.
 					s.signChunk(s.chunkBufLen, true)
+					if s.err != nil {
+						return 0, s.err
+					}
 					break
 				}
 			}
@@ -481,6 +513,9 @@ func (s *StreamingReader) Read(buf []byte) (int, error) {
 						// Trailer must be set now.
 						s.addSignedTrailer(s.trailer)
 					}
+					if s.err != nil {
+						return 0, s.err
+					}
 					break
 				}
 				return 0, err
@@ -212,12 +212,27 @@ func PreSignV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, loc
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
+	signed, _ := preSignV4(req, accessKeyID, sessionToken, location, ServiceTypeS3, expires, secretKeySign(secretAccessKey))
+	return signed
+}
 
+// PreSignV4Outposts presign the request for S3 on Outposts (service name s3-outposts).
+func PreSignV4Outposts(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string, expires int64) *http.Request {
+	// Presign is not needed for anonymous credentials.
+	if accessKeyID == "" || secretAccessKey == "" {
+		return &req
+	}
+	signed, _ := preSignV4(req, accessKeyID, sessionToken, location, ServiceTypeS3Outposts, expires, secretKeySign(secretAccessKey))
+	return signed
+}
+
+// Internal function presigning with sign for different service types.
+func preSignV4(req http.Request, accessKeyID, sessionToken, location, serviceType string, expires int64, sign signFunc) (*http.Request, error) {
 	// Initial time.
 	t := time.Now().UTC()
 
 	// Get credential string.
-	credential := GetCredential(accessKeyID, location, t, ServiceTypeS3)
+	credential := GetCredential(accessKeyID, location, t, serviceType)
 
 	// Get all signed headers.
 	signedHeaders := getSignedHeaders(req, v4IgnoredHeaders)
@@ -243,50 +258,18 @@ func PreSignV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, loc
 	canonicalRequest := getCanonicalRequest(req, v4IgnoredHeaders, getHashedPayload(req))
 
 	// Get string to sign from canonical request.
-	stringToSign := getStringToSignV4(t, location, canonicalRequest, ServiceTypeS3)
-
-	// Gext hmac signing key.
-	signingKey := getSigningKey(secretAccessKey, location, t, ServiceTypeS3)
+	stringToSign := getStringToSignV4(t, location, canonicalRequest, serviceType)
 
 	// Calculate signature.
-	signature := getSignature(signingKey, stringToSign)
+	signature, err := sign(Scope{Time: t, Region: location, ServiceType: serviceType}, stringToSign)
+	if err != nil {
+		return nil, err
+	}
 
 	// Add signature header to RawQuery.
 	req.URL.RawQuery += "&X-Amz-Signature=" + signature
 
-	return &req
-}
-
-// PreSignV4Outposts presign the request for S3 on Outposts (service name s3-outposts).
-func PreSignV4Outposts(req http.Request, accessKeyID, secretAccessKey, sessionToken, location string, expires int64) *http.Request {
-	// Presign is not needed for anonymous credentials.
-	if accessKeyID == "" || secretAccessKey == "" {
-		return &req
-	}
-
-	t := time.Now().UTC()
-	credential := GetCredential(accessKeyID, location, t, ServiceTypeS3Outposts)
-	signedHeaders := getSignedHeaders(req, v4IgnoredHeaders)
-	query := req.URL.Query()
-	query.Set("X-Amz-Algorithm", signV4Algorithm)
-	query.Set("X-Amz-Date", t.Format(iso8601DateFormat))
-	query.Set("X-Amz-Expires", strconv.FormatInt(expires, 10))
-	query.Set("X-Amz-SignedHeaders", signedHeaders)
-	query.Set("X-Amz-Credential", credential)
-	if sessionToken != "" {
-		if v := req.Header.Get("x-amz-s3session-token"); v != "" {
-			query.Set("X-Amz-S3session-Token", sessionToken)
-		} else {
-			query.Set("X-Amz-Security-Token", sessionToken)
-		}
-	}
-	req.URL.RawQuery = query.Encode()
-	canonicalRequest := getCanonicalRequest(req, v4IgnoredHeaders, getHashedPayload(req))
-	stringToSign := getStringToSignV4(t, location, canonicalRequest, ServiceTypeS3Outposts)
-	signingKey := getSigningKey(secretAccessKey, location, t, ServiceTypeS3Outposts)
-	signature := getSignature(signingKey, stringToSign)
-	req.URL.RawQuery += "&X-Amz-Signature=" + signature
-	return &req
+	return &req, nil
 }
 
 // PostPresignSignatureV4 - presigned signature for PostPolicy
@@ -310,7 +293,12 @@ func signV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, locati
 	if accessKeyID == "" || secretAccessKey == "" {
 		return &req
 	}
+	signed, _ := signV4With(req, accessKeyID, sessionToken, location, serviceType, trailer, secretKeySign(secretAccessKey))
+	return signed
+}
 
+// Internal function signing with sign for different service types.
+func signV4With(req http.Request, accessKeyID, sessionToken, location, serviceType string, trailer http.Header, sign signFunc) (*http.Request, error) {
 	// Initial time.
 	t := time.Now().UTC()
 
@@ -349,9 +337,6 @@ func signV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, locati
 	// Get string to sign from canonical request.
 	stringToSign := getStringToSignV4(t, location, canonicalRequest, serviceType)
 
-	// Get hmac signing key.
-	signingKey := getSigningKey(secretAccessKey, location, t, serviceType)
-
 	// Get credential string.
 	credential := GetCredential(accessKeyID, location, t, serviceType)
 
@@ -359,7 +344,10 @@ func signV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, locati
 	signedHeaders := getSignedHeaders(req, v4IgnoredHeaders)
 
 	// Calculate signature.
-	signature := getSignature(signingKey, stringToSign)
+	signature, err := sign(Scope{Time: t, Region: location, ServiceType: serviceType}, stringToSign)
+	if err != nil {
+		return nil, err
+	}
 
 	// If regular request, construct the final authorization header.
 	parts := []string{
@@ -375,9 +363,9 @@ func signV4(req http.Request, accessKeyID, secretAccessKey, sessionToken, locati
 	if len(trailer) > 0 {
 		// Use custom chunked encoding.
 		req.Trailer = trailer
-		return StreamingUnsignedV4(&req, sessionToken, req.ContentLength, t)
+		return StreamingUnsignedV4(&req, sessionToken, req.ContentLength, t), nil
 	}
-	return &req
+	return &req, nil
 }
 
 // UnsignedTrailer will do chunked encoding with a custom trailer.
@@ -0,0 +1,362 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	md5simd "github.com/minio/md5-simd"
+)
+
+// Signature v4a related constants.
+const (
+	signV4AAlgorithm                 = "AWS4-ECDSA-P256-SHA256"
+	streamingSignV4AAlgorithm        = "STREAMING-AWS4-ECDSA-P256-SHA256-PAYLOAD"
+	streamingSignV4ATrailerAlgorithm = "STREAMING-AWS4-ECDSA-P256-SHA256-PAYLOAD-TRAILER"
+	streamingV4APayloadHdr           = "AWS4-ECDSA-P256-SHA256-PAYLOAD"
+	streamingV4ATrailerHdr           = "AWS4-ECDSA-P256-SHA256-TRAILER"
+
+	// v4aSignatureStrLen is the maximum length of a hex encoded ASN.1
+	// ECDSA P-256 signature, chunk signatures are padded with '*' to
+	// this length so that the stream length is known in advance.
+	v4aSignatureStrLen = 144
+
+	// maxV4AKeys bounds the number of cached signature v4a keys.
+	maxV4AKeys = 64
+)
+
+// p256NMinus2 is the order of P-256 minus two, the upper bound for key
+// candidates.
+var p256NMinus2 = new(big.Int).Sub(elliptic.P256().Params().N, big.NewInt(2))
+
+// DeriveV4AKey derives the ECDSA P-256 key used by signature v4a from an
+// access key pair. The key is derived with the NIST SP 800-108 counter mode
+// KDF using HMAC-SHA256, retrying with an incremented external counter
+// until the candidate is a valid private key.
+func DeriveV4AKey(accessKeyID, secretAccessKey string) (*ecdsa.PrivateKey, error) {
+	inputKey := []byte("AWS4A" + secretAccessKey)
+	for counter := 1; counter <= 0xff; counter++ {
+		// A single KDF iteration yields the 256 bits needed.
+		h := hmac.New(sha256.New, inputKey)
+		h.Write([]byte{0, 0, 0, 1})
+		h.Write([]byte(signV4AAlgorithm))
+		h.Write([]byte{0})
+		h.Write([]byte(accessKeyID))
+		h.Write([]byte{byte(counter)})
+		h.Write([]byte{0, 0, 1, 0})
+
+		candidate := new(big.Int).SetBytes(h.Sum(nil))
+		if candidate.Cmp(p256NMinus2) <= 0 {
+			d := candidate.Add(candidate, big.NewInt(1))
+			return ecdsa.ParseRawPrivateKey(elliptic.P256(), d.FillBytes(make([]byte, 32)))
+		}
+	}
+	return nil, errors.New("unable to derive signature v4a key")
+}
+
+// v4aKeys caches the derived signature v4a keys by the hash of their
+// access key pair, as deriving a key costs up to 255 HMAC computations.
+var v4aKeys = struct {
+	sync.Mutex
+	m map[[sha256.Size]byte]*ecdsa.PrivateKey
+}{m: make(map[[sha256.Size]byte]*ecdsa.PrivateKey)}
+
+// cachedV4AKey returns the cached signature v4a key of the access key
+// pair, deriving it on a cache miss.
+func cachedV4AKey(accessKeyID, secretAccessKey string) (*ecdsa.PrivateKey, error) {
+	id := sha256.Sum256([]byte(accessKeyID + "\x00" + secretAccessKey))
+	v4aKeys.Lock()
+	key, ok := v4aKeys.m[id]
+	v4aKeys.Unlock()
+	if ok {
+		return key, nil
+	}
+	key, err := DeriveV4AKey(accessKeyID, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+	v4aKeys.Lock()
+	if len(v4aKeys.m) >= maxV4AKeys {
+		// Temporary credentials rotate, drop the keys of old ones.
+		clear(v4aKeys.m)
+	}
+	v4aKeys.m[id] = key
+	v4aKeys.Unlock()
+	return key, nil
+}
+
+// getScopeV4A generate the region independent scope of signature v4a.
+func getScopeV4A(t time.Time, serviceType string) string {
+	return strings.Join([]string{
+		t.Format(yyyymmdd),
+		serviceType,
+		"aws4_request",
+	}, "/")
+}
+
+// getStringToSignV4A a string based on selected query values.
+func getStringToSignV4A(t time.Time, canonicalRequest, serviceType string) string {
+	return signV4AAlgorithm + "\n" + t.Format(iso8601DateFormat) + "\n" +
+		getScopeV4A(t, serviceType) + "\n" +
+		hex.EncodeToString(sum256([]byte(canonicalRequest)))
+}
+
+// padV4ASignature pads a chunk signature to its maximum length.
+func padV4ASignature(signature string) string {
+	return signature + strings.Repeat("*", v4aSignatureStrLen-len(signature))
+}
+
+// v4aSigner signs with the key derived from the access key pair.
+type v4aSigner struct {
+	key         *ecdsa.PrivateKey
+	t           time.Time
+	serviceType string
+}
+
+func newV4ASigner(accessKeyID, secretAccessKey string, t time.Time, serviceType string) (*v4aSigner, error) {
+	key, err := cachedV4AKey(accessKeyID, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+	return &v4aSigner{key: key, t: t, serviceType: serviceType}, nil
+}
+
+// sign returns the hex encoded ASN.1 ECDSA signature of stringToSign.
+func (s *v4aSigner) sign(stringToSign string) (string, error) {
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, sum256([]byte(stringToSign)))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
+
+// chunkSignature returns the unpadded signature of a payload chunk.
+func (s *v4aSigner) chunkSignature(previousSignature, chunkChecksum string) (string, error) {
+	return s.sign(buildChunkStringToSignV4A(s.t, s.serviceType, previousSignature, chunkChecksum))
+}
+
+// trailerSignature returns the unpadded signature of the trailer chunk.
+func (s *v4aSigner) trailerSignature(previousSignature, chunkChecksum string) (string, error) {
+	return s.sign(buildTrailerChunkStringToSignV4A(s.t, s.serviceType, previousSignature, chunkChecksum))
+}
+
+// buildChunkStringToSignV4A - returns the string to sign of a payload
+// chunk with signature v4a.
+func buildChunkStringToSignV4A(t time.Time, serviceType, previousSignature, chunkChecksum string) string {
+	return strings.Join([]string{
+		streamingV4APayloadHdr,
+		t.Format(iso8601DateFormat),
+		getScopeV4A(t, serviceType),
+		previousSignature,
+		emptySHA256,
+		chunkChecksum,
+	}, "\n")
+}
+
+// buildTrailerChunkStringToSignV4A - returns the string to sign of the
+// trailer chunk with signature v4a.
+func buildTrailerChunkStringToSignV4A(t time.Time, serviceType, previousSignature, chunkChecksum string) string {
+	return strings.Join([]string{
+		streamingV4ATrailerHdr,
+		t.Format(iso8601DateFormat),
+		getScopeV4A(t, serviceType),
+		previousSignature,
+		chunkChecksum,
+	}, "\n")
+}
+
+// PreSignV4A presign the request with signature v4a for the regions in
+// regionSet, "*" signs for all regions.
+func PreSignV4A(req http.Request, accessKeyID, secretAccessKey, sessionToken string, regionSet []string, expires int64) (*http.Request, error) {
+	// Presign is not needed for anonymous credentials.
+	if accessKeyID == "" || secretAccessKey == "" {
+		return &req, nil
+	}
+
+	t := time.Now().UTC()
+	s, err := newV4ASigner(accessKeyID, secretAccessKey, t, ServiceTypeS3)
+	if err != nil {
+		return nil, err
+	}
+
+	signedHeaders := getSignedHeaders(req, v4IgnoredHeaders)
+	query := req.URL.Query()
+	query.Set("X-Amz-Algorithm", signV4AAlgorithm)
+	query.Set("X-Amz-Date", t.Format(iso8601DateFormat))
+	query.Set("X-Amz-Expires", strconv.FormatInt(expires, 10))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	query.Set("X-Amz-Credential", accessKeyID+"/"+getScopeV4A(t, ServiceTypeS3))
+	query.Set("X-Amz-Region-Set", strings.Join(regionSet, ","))
+	if sessionToken != "" {
+		query.Set("X-Amz-Security-Token", sessionToken)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	canonicalRequest := getCanonicalRequest(req, v4IgnoredHeaders, getHashedPayload(req))
+	signature, err := s.sign(getStringToSignV4A(t, canonicalRequest, ServiceTypeS3))
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery += "&X-Amz-Signature=" + signature
+	return &req, nil
+}
+
+// signV4A is the signature v4a counterpart of signV4.
+func signV4A(req http.Request, accessKeyID, secretAccessKey, sessionToken string, regionSet []string, serviceType string, trailer http.Header) (*http.Request, error) {
+	// Signature calculation is not needed for anonymous credentials.
+	if accessKeyID == "" || secretAccessKey == "" {
+		return &req, nil
+	}
+
+	t := time.Now().UTC()
+	s, err := newV4ASigner(accessKeyID, secretAccessKey, t, serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Amz-Date", t.Format(iso8601DateFormat))
+	req.Header.Set("X-Amz-Region-Set", strings.Join(regionSet, ","))
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+	if len(trailer) > 0 {
+		for k := range trailer {
+			req.Header.Add("X-Amz-Trailer", strings.ToLower(k))
+		}
+		req.Header.Set("Content-Encoding", "aws-chunked")
+		req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(req.ContentLength, 10))
+	}
+
+	canonicalRequest := getCanonicalRequest(req, v4IgnoredHeaders, getHashedPayload(req))
+	signature, err := s.sign(getStringToSignV4A(t, canonicalRequest, serviceType))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []string{
+		signV4AAlgorithm + " Credential=" + accessKeyID + "/" + getScopeV4A(t, serviceType),
+		"SignedHeaders=" + getSignedHeaders(req, v4IgnoredHeaders),
+		"Signature=" + signature,
+	}
+	req.Header.Set("Authorization", strings.Join(parts, ", "))
+
+	if len(trailer) > 0 {
+		// Use custom chunked encoding.
+		req.Trailer = trailer
+		return StreamingUnsignedV4(&req, sessionToken, req.ContentLength, t), nil
+	}
+	return &req, nil
+}
+
+// SignV4A sign the request before Do() with signature v4a for the regions
+// in regionSet, "*" signs for all regions. Signature v4a is required by
+// multi-region access points.
+func SignV4A(req http.Request, accessKeyID, secretAccessKey, sessionToken string, regionSet []string) (*http.Request, error) {
+	return signV4A(req, accessKeyID, secretAccessKey, sessionToken, regionSet, ServiceTypeS3, nil)
+}
+
+// SignV4ATrailer sign the request before Do() with signature v4a and an
+// unsigned trailer.
+func SignV4ATrailer(req http.Request, accessKeyID, secretAccessKey, sessionToken string, regionSet []string, trailer http.Header) (*http.Request, error) {
+	return signV4A(req, accessKeyID, secretAccessKey, sessionToken, regionSet, ServiceTypeS3, trailer)
+}
+
+// StreamingSignV4A - provides chunked upload signature v4a support by
+// implementing io.Reader. Chunk signatures are padded with '*' to a fixed
+// length.
+func StreamingSignV4A(req *http.Request, accessKeyID, secretAccessKey, sessionToken string,
+	regionSet []string, dataLen int64, reqTime time.Time, sh256 md5simd.Hasher,
+) (*http.Request, error) {
+	s, err := newV4ASigner(accessKeyID, secretAccessKey, reqTime, ServiceTypeS3)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set headers needed for streaming signature.
+	if len(req.Trailer) == 0 {
+		req.Header.Set("X-Amz-Content-Sha256", streamingSignV4AAlgorithm)
+	} else {
+		req.Header.Set("X-Amz-Content-Sha256", streamingSignV4ATrailerAlgorithm)
+		for k := range req.Trailer {
+			req.Header.Add("X-Amz-Trailer", strings.ToLower(k))
+		}
+		req.TransferEncoding = []string{"aws-chunked"}
+	}
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+	req.Header.Set("X-Amz-Date", reqTime.Format(iso8601DateFormat))
+	req.Header.Set("X-Amz-Region-Set", strings.Join(regionSet, ","))
+	req.ContentLength = getStreamLength(dataLen, int64(payloadChunkSize), v4aSignatureStrLen, req.Trailer)
+	req.Header.Set("x-amz-decoded-content-length", strconv.FormatInt(dataLen, 10))
+
+	if req.Body == nil {
+		req.Body = io.NopCloser(bytes.NewReader([]byte("")))
+	}
+
+	stReader := &StreamingReader{
+		baseReadCloser:  req.Body,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    sessionToken,
+		reqTime:         reqTime,
+		chunkBuf:        make([]byte, payloadChunkSize),
+		contentLen:      dataLen,
+		chunkNum:        1,
+		totalChunks:     int((dataLen+payloadChunkSize-1)/payloadChunkSize) + 1,
+		lastChunkSize:   int(dataLen % payloadChunkSize),
+		sh256:           sh256,
+		v4a:             s,
+	}
+	if len(req.Trailer) > 0 {
+		stReader.trailer = req.Trailer
+		req.Trailer = nil
+	}
+
+	// Compute the seed signature.
+	canonicalRequest := getCanonicalRequest(*req, ignoredStreamingHeaders, getHashedPayload(*req))
+	stReader.seedSignature, err = s.sign(getStringToSignV4A(reqTime, canonicalRequest, ServiceTypeS3))
+	if err != nil {
+		return nil, err
+	}
+
+	authParts := []string{
+		signV4AAlgorithm + " Credential=" + accessKeyID + "/" + getScopeV4A(reqTime, ServiceTypeS3),
+		"SignedHeaders=" + getSignedHeaders(*req, ignoredStreamingHeaders),
+		"Signature=" + stReader.seedSignature,
+	}
+	req.Header.Set("Authorization", strings.Join(authParts, ","))
+
+	stReader.prevSignature = stReader.seedSignature
+	req.Body = stReader
+	return req, nil
+}
@@ -0,0 +1,168 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeriveV4AKey(t *testing.T) {
+	key, err := DeriveV4AKey("AKISORANDOMAASORANDOM", "q+jcrXGc+0zWN6uzclKVhvMmUsIfRPa4rlRandom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := fmt.Sprintf("%064X", key.X)
+	y := fmt.Sprintf("%064X", key.Y)
+	if x != "15D242CEEBF8D8169FD6A8B5A746C41140414C3B07579038DA06AF89190FFFCB" ||
+		y != "0515242CEDD82E94799482E4C0514B505AFCCF2C0C98D6A553BF539F424C5EC0" {
+		t.Fatalf("unexpected public key (%s, %s)", x, y)
+	}
+}
+
+func TestCachedV4AKey(t *testing.T) {
+	key, err := cachedV4AKey("AKISORANDOMAASORANDOM", "q+jcrXGc+0zWN6uzclKVhvMmUsIfRPa4rlRandom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := cachedV4AKey("AKISORANDOMAASORANDOM", "q+jcrXGc+0zWN6uzclKVhvMmUsIfRPa4rlRandom"); cached != key {
+		t.Fatal("expected the key to be cached")
+	}
+	other, err := cachedV4AKey("AKISORANDOMAASORANDOM", "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Equal(key) {
+		t.Fatal("expected another key for another secret")
+	}
+}
+
+// mustSign returns a function failing t if signing failed.
+func mustSign(t *testing.T) func(*http.Request, error) *http.Request {
+	return func(req *http.Request, err error) *http.Request {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+}
+
+func TestSignV4A(t *testing.T) {
+	req := newVerifyRequest(http.MethodPut, "http://s3.example.com/bucket/object?tagging", "payload")
+	req = toServerRequest(t, mustSign(t)(SignV4A(*req, verifyAccessKey, verifySecretKey, "token", []string{"us-east-1", "eu-west-1"})))
+	if !strings.HasPrefix(req.Header.Get("Authorization"), signV4AAlgorithm+" ") {
+		t.Fatalf("unexpected authorization %q", req.Header.Get("Authorization"))
+	}
+
+	auth, err := VerifyV4(req, verifyLookup, VerifyOptions{Region: "eu-west-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.Region != "" || !slices.Equal(auth.RegionSet, []string{"us-east-1", "eu-west-1"}) {
+		t.Fatalf("unexpected auth %+v", auth)
+	}
+	if data, err := io.ReadAll(req.Body); err != nil || string(data) != "payload" {
+		t.Fatalf("unexpected body %q: %v", data, err)
+	}
+
+	req = newVerifyRequest(http.MethodPut, "http://s3.example.com/bucket/object", "payload")
+	req = toServerRequest(t, mustSign(t)(SignV4A(*req, verifyAccessKey, verifySecretKey, "", []string{"us-east-1"})))
+	if _, err = VerifyV4(req, verifyLookup, VerifyOptions{Region: "eu-west-1"}); !errors.Is(err, ErrInvalidScope) {
+		t.Fatalf("expected %v, got %v", ErrInvalidScope, err)
+	}
+	req.Header.Set("X-Amz-Region-Set", "*")
+	if _, err = VerifyV4(req, verifyLookup, VerifyOptions{}); !errors.Is(err, ErrSignatureDoesNotMatch) {
+		t.Fatalf("expected %v, got %v", ErrSignatureDoesNotMatch, err)
+	}
+}
+
+func TestPreSignV4A(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://s3.example.com/bucket/object", nil)
+	req = toServerRequest(t, mustSign(t)(PreSignV4A(*req, verifyAccessKey, verifySecretKey, "", []string{"*"}, 60)))
+
+	auth, err := VerifyPresignedV4(req, verifyLookup, VerifyOptions{Region: "ap-south-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(auth.RegionSet, []string{"*"}) || auth.Expires != time.Minute {
+		t.Fatalf("unexpected auth %+v", auth)
+	}
+	req.URL.RawQuery = strings.Replace(req.URL.RawQuery, "X-Amz-Expires=60", "X-Amz-Expires=61", 1)
+	if _, err = VerifyPresignedV4(req, verifyLookup, VerifyOptions{}); !errors.Is(err, ErrSignatureDoesNotMatch) {
+		t.Fatalf("expected %v, got %v", ErrSignatureDoesNotMatch, err)
+	}
+}
+
+func TestStreamingSignV4A(t *testing.T) {
+	reqTime, _ := time.Parse(iso8601DateFormat, "20130524T000000Z")
+	opts := VerifyOptions{Region: "us-east-1", Now: func() time.Time { return reqTime }}
+	data := bytes.Repeat([]byte("a"), 65*1024+17)
+
+	sign := func(trailer http.Header) *http.Request {
+		req, _ := http.NewRequest(http.MethodPut, "http://s3.example.com/bucket/object", bytes.NewReader(data))
+		req.Trailer = trailer
+		req = mustSign(t)(StreamingSignV4A(req, verifyAccessKey, verifySecretKey, "", []string{"us-east-1"}, int64(len(data)), reqTime, newSHA256Hasher()))
+		signed, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(signed)) != req.ContentLength {
+			t.Fatalf("expected stream length %d, got %d", req.ContentLength, len(signed))
+		}
+		req.Body = io.NopCloser(bytes.NewReader(signed))
+		req.TransferEncoding = nil
+		return toServerRequest(t, req)
+	}
+
+	for _, trailer := range []http.Header{nil, {"X-Amz-Checksum-Crc32c": {"wdBDMA=="}}} {
+		req := sign(trailer)
+		if _, err := VerifyV4(req, verifyLookup, opts); err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatal("decoded payload does not match")
+		}
+		if trailer != nil && req.Trailer.Get("X-Amz-Checksum-Crc32c") != "wdBDMA==" {
+			t.Fatalf("unexpected trailer %v", req.Trailer)
+		}
+	}
+
+	req := sign(nil)
+	signed, _ := io.ReadAll(req.Body)
+	i := bytes.LastIndex(signed, []byte("aaaa"))
+	signed[i] = 'b'
+	req.Body = io.NopCloser(bytes.NewReader(signed))
+	if _, err := VerifyV4(req, verifyLookup, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(req.Body); !errors.Is(err, ErrChunkSignatureDoesNotMatch) {
+		t.Fatalf("expected %v, got %v", ErrChunkSignatureDoesNotMatch, err)
+	}
+}
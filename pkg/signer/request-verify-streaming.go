@@ -0,0 +1,272 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	unsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+
+	// maxChunkSize limits the size of a single aws-chunked chunk
+	// accepted by the verifier.
+	maxChunkSize = 16 << 20
+
+	// maxTrailers limits the number of trailing headers.
+	maxTrailers = 100
+)
+
+// verifyPayload replaces req.Body by a reader verifying the payload
+// against hashedPayload.
+func verifyPayload(req *http.Request, verifier signatureVerifier, hashedPayload string) error {
+	if req.Body == nil {
+		req.Body = http.NoBody
+	}
+	switch hashedPayload {
+	case unsignedPayload:
+		return nil
+	case streamingSignAlgorithm, streamingSignTrailerAlgorithm, unsignedPayloadTrailer,
+		streamingSignV4AAlgorithm, streamingSignV4ATrailerAlgorithm:
+		decodedLen := int64(-1)
+		if v := req.Header.Get("X-Amz-Decoded-Content-Length"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return ErrMalformedChunkedEncoding
+			}
+			decodedLen = n
+		}
+		req.Body = &chunkedVerifyReader{
+			req:       req,
+			body:      req.Body,
+			br:        bufio.NewReader(req.Body),
+			signed:    hashedPayload != unsignedPayloadTrailer,
+			trailer:   hashedPayload != streamingSignAlgorithm && hashedPayload != streamingSignV4AAlgorithm,
+			verifier:  verifier,
+			prevSig:   verifier.auth.Signature,
+			remaining: decodedLen,
+		}
+		return nil
+	}
+	sum, err := hex.DecodeString(hashedPayload)
+	if err != nil || len(sum) != sha256.Size {
+		return ErrMissingContentSHA256
+	}
+	req.Body = &sha256VerifyReader{body: req.Body, hash: sha256.New(), sum: sum}
+	return nil
+}
+
+// sha256VerifyReader returns ErrContentSHA256Mismatch at EOF if the data
+// read does not match the expected SHA-256 sum.
+type sha256VerifyReader struct {
+	body io.ReadCloser
+	hash hash.Hash
+	sum  []byte
+}
+
+func (r *sha256VerifyReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.sum) {
+		return n, ErrContentSHA256Mismatch
+	}
+	return n, err
+}
+
+func (r *sha256VerifyReader) Close() error {
+	return r.body.Close()
+}
+
+// chunkedVerifyReader decodes an aws-chunked payload, verifying the chunk
+// and trailer signatures of signed payloads.
+type chunkedVerifyReader struct {
+	req      *http.Request
+	body     io.ReadCloser
+	br       *bufio.Reader
+	signed   bool
+	trailer  bool
+	verifier signatureVerifier
+	prevSig  string
+
+	// remaining is the decoded length left to read, -1 if unknown.
+	remaining int64
+	chunk     []byte
+	err       error
+}
+
+func (r *chunkedVerifyReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 && r.err == nil {
+		r.err = r.nextChunk()
+	}
+	if len(r.chunk) > 0 {
+		n := copy(p, r.chunk)
+		r.chunk = r.chunk[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *chunkedVerifyReader) Close() error {
+	return r.body.Close()
+}
+
+// nextChunk reads and verifies the next chunk, it returns io.EOF after
+// the final chunk and its trailer.
+func (r *chunkedVerifyReader) nextChunk() error {
+	line, err := r.readLine()
+	if err != nil {
+		return err
+	}
+	sizeStr, params, _ := strings.Cut(line, ";")
+	size, err := strconv.ParseInt(sizeStr, 16, 64)
+	if err != nil || size < 0 || size > maxChunkSize {
+		return ErrMalformedChunkedEncoding
+	}
+	if r.remaining >= 0 && size > r.remaining {
+		return ErrMalformedChunkedEncoding
+	}
+	chunk := make([]byte, size)
+	if _, err = io.ReadFull(r.br, chunk); err != nil {
+		return ErrMalformedChunkedEncoding
+	}
+
+	if r.signed {
+		signature, ok := strings.CutPrefix(params, "chunk-signature=")
+		if !ok {
+			return ErrMalformedChunkedEncoding
+		}
+		// Signature v4a chunk signatures are padded with '*'.
+		signature = strings.TrimRight(signature, "*")
+		sum := sha256.Sum256(chunk)
+		if !r.verifier.verify(r.verifier.chunkStringToSign(r.prevSig, hex.EncodeToString(sum[:])), signature) {
+			return ErrChunkSignatureDoesNotMatch
+		}
+		r.prevSig = signature
+	}
+
+	if size > 0 {
+		if line, err = r.readLine(); err != nil || line != "" {
+			return ErrMalformedChunkedEncoding
+		}
+		if r.remaining >= 0 {
+			r.remaining -= size
+		}
+		r.chunk = chunk
+		return nil
+	}
+
+	if r.remaining > 0 {
+		return ErrMalformedChunkedEncoding
+	}
+	if r.trailer {
+		if err = r.readTrailer(); err != nil {
+			return err
+		}
+	} else if line, err = r.readLine(); err != nil || line != "" {
+		return ErrMalformedChunkedEncoding
+	}
+	return io.EOF
+}
+
+// readTrailer reads the trailing headers after the final chunk, they are
+// terminated by an empty line followed by the trailer signature for
+// signed payloads.
+func (r *chunkedVerifyReader) readTrailer() error {
+	var raw bytes.Buffer
+	trailer := make(http.Header)
+	for {
+		line, err := r.readRawLine()
+		if err != nil {
+			return err
+		}
+		if line == "\r\n" {
+			break
+		}
+		raw.WriteString(line)
+		k, v, ok := strings.Cut(strings.TrimRight(line, "\r\n"), trailerKVSeparator)
+		if !ok {
+			return ErrMalformedChunkedEncoding
+		}
+		trailer.Add(k, v)
+		if len(trailer) > maxTrailers {
+			return ErrMalformedChunkedEncoding
+		}
+	}
+
+	if r.signed {
+		line, err := r.readLine()
+		if err != nil {
+			return err
+		}
+		signature, ok := strings.CutPrefix(line, trailerSignature+trailerKVSeparator)
+		if !ok {
+			return ErrMalformedChunkedEncoding
+		}
+		signature = strings.TrimRight(signature, "*")
+		sum := sha256.Sum256(raw.Bytes())
+		if !r.verifier.verify(r.verifier.trailerStringToSign(r.prevSig, hex.EncodeToString(sum[:])), signature) {
+			return ErrChunkSignatureDoesNotMatch
+		}
+	}
+	if line, err := r.readLine(); err != nil || line != "" {
+		return ErrMalformedChunkedEncoding
+	}
+
+	if r.req.Trailer == nil {
+		r.req.Trailer = make(http.Header)
+	}
+	for k, v := range trailer {
+		r.req.Trailer[k] = v
+	}
+	return nil
+}
+
+// readRawLine reads a line including its line terminator, lines longer
+// than the read buffer are rejected.
+func (r *chunkedVerifyReader) readRawLine() (string, error) {
+	line, err := r.br.ReadSlice('\n')
+	switch err {
+	case nil:
+		return string(line), nil
+	case io.EOF, bufio.ErrBufferFull:
+		return "", ErrMalformedChunkedEncoding
+	default:
+		return "", err
+	}
+}
+
+// readLine reads a CRLF terminated line, returning it without CRLF.
+func (r *chunkedVerifyReader) readLine() (string, error) {
+	line, err := r.readRawLine()
+	if err != nil {
+		return "", err
+	}
+	line, ok := strings.CutSuffix(line, "\r\n")
+	if !ok {
+		return "", ErrMalformedChunkedEncoding
+	}
+	return line, nil
+}
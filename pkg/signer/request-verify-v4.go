@@ -0,0 +1,406 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors returned by VerifyV4 and VerifyPresignedV4, and by reads of
+// verified request bodies.
+var (
+	ErrMissingAuthorization       = errors.New("request is not signed with signature v4")
+	ErrUnsupportedAlgorithm       = errors.New("unsupported signature algorithm")
+	ErrMalformedAuthorization     = errors.New("malformed signature v4 authorization")
+	ErrMalformedDate              = errors.New("malformed or missing request date")
+	ErrInvalidScope               = errors.New("credential scope does not match the request")
+	ErrRequestTimeTooSkewed       = errors.New("difference between request time and server time is too large")
+	ErrExpiredRequest             = errors.New("presigned request has expired")
+	ErrUnsignedHeader             = errors.New("required header is not signed")
+	ErrMissingContentSHA256       = errors.New("missing x-amz-content-sha256 header")
+	ErrSignatureDoesNotMatch      = errors.New("signature does not match")
+	ErrContentSHA256Mismatch      = errors.New("payload does not match x-amz-content-sha256")
+	ErrMalformedChunkedEncoding   = errors.New("malformed aws-chunked payload")
+	ErrChunkSignatureDoesNotMatch = errors.New("chunk signature does not match")
+)
+
+const (
+	// defaultMaxClockSkew is the maximum difference between request and
+	// server time accepted by default.
+	defaultMaxClockSkew = 15 * time.Minute
+
+	// maxPresignExpiry is the maximum validity of presigned requests.
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// CredentialLookup returns the secret access key of accessKeyID, errors
+// are returned unchanged by the verify functions.
+type CredentialLookup func(accessKeyID string) (secretAccessKey string, err error)
+
+// VerifyOptions configures signature verification.
+type VerifyOptions struct {
+	// Region the credential scope must match, any region is
+	// accepted if empty. Signature v4a requests are accepted if
+	// their region set contains Region or "*".
+	Region string
+
+	// ServiceType the credential scope must match, defaults to
+	// ServiceTypeS3.
+	ServiceType string
+
+	// MaxClockSkew is the maximum difference between request
+	// and server time, defaults to 15 minutes.
+	MaxClockSkew time.Duration
+
+	// Now returns the server time, defaults to time.Now.
+	Now func() time.Time
+}
+
+func (opts VerifyOptions) serviceType() string {
+	if opts.ServiceType == "" {
+		return ServiceTypeS3
+	}
+	return opts.ServiceType
+}
+
+func (opts VerifyOptions) maxClockSkew() time.Duration {
+	if opts.MaxClockSkew <= 0 {
+		return defaultMaxClockSkew
+	}
+	return opts.MaxClockSkew
+}
+
+func (opts VerifyOptions) now() time.Time {
+	if opts.Now == nil {
+		return time.Now().UTC()
+	}
+	return opts.Now().UTC()
+}
+
+// AuthV4 describes a request with a verified signature.
+type AuthV4 struct {
+	AccessKeyID string
+	Region      string
+	ServiceType string
+
+	// RegionSet is set instead of Region for signature v4a.
+	RegionSet []string
+
+	Time          time.Time
+	SignedHeaders []string
+	Signature     string
+
+	// Expires is the validity of presigned requests.
+	Expires time.Duration
+}
+
+// authV4Fields are the signature fields sent in the Authorization
+// header or in the query of presigned requests.
+type authV4Fields struct {
+	algorithm     string
+	regionSet     string
+	credential    string
+	signedHeaders string
+	signature     string
+}
+
+// VerifyV4 verifies the signature v4 or v4a Authorization header of req,
+// as created by SignV4, SignV4A, StreamingSignV4 and StreamingSignV4A,
+// with the secret key returned by lookup. The credential scope, the
+// request time and the signed headers are checked, the host and
+// x-amz-content-sha256 headers must be signed.
+//
+// On success req.Body is replaced by a reader verifying the payload
+// against x-amz-content-sha256 while it is read. aws-chunked payloads are
+// decoded, every chunk signature is verified and trailing headers are
+// stored in req.Trailer once the body is read to EOF. Reads fail with
+// ErrContentSHA256Mismatch or ErrChunkSignatureDoesNotMatch if the
+// payload does not match its signature.
+func VerifyV4(req *http.Request, lookup CredentialLookup, opts VerifyOptions) (AuthV4, error) {
+	authz := req.Header.Get("Authorization")
+	if authz == "" {
+		return AuthV4{}, ErrMissingAuthorization
+	}
+	algorithm, rest, _ := strings.Cut(authz, " ")
+	if algorithm != signV4Algorithm && algorithm != signV4AAlgorithm {
+		return AuthV4{}, ErrUnsupportedAlgorithm
+	}
+	fields := authV4Fields{
+		algorithm: algorithm,
+		regionSet: req.Header.Get("X-Amz-Region-Set"),
+	}
+	for _, field := range strings.Split(rest, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return AuthV4{}, ErrMalformedAuthorization
+		}
+		switch k {
+		case "Credential":
+			fields.credential = v
+		case "SignedHeaders":
+			fields.signedHeaders = v
+		case "Signature":
+			fields.signature = v
+		}
+	}
+
+	t, err := parseRequestTime(req)
+	if err != nil {
+		return AuthV4{}, err
+	}
+	if d := opts.now().Sub(t).Abs(); d > opts.maxClockSkew() {
+		return AuthV4{}, ErrRequestTimeTooSkewed
+	}
+
+	auth, verifier, err := parseAuthV4Fields(fields, t, lookup, opts)
+	if err != nil {
+		return AuthV4{}, err
+	}
+	if !slices.Contains(auth.SignedHeaders, "x-amz-content-sha256") {
+		return AuthV4{}, ErrUnsignedHeader
+	}
+	for k := range req.Header {
+		// All x-amz-* headers must be signed.
+		if strings.HasPrefix(k, "X-Amz-") && !slices.Contains(auth.SignedHeaders, strings.ToLower(k)) {
+			return AuthV4{}, ErrUnsignedHeader
+		}
+	}
+	hashedPayload := req.Header.Get("X-Amz-Content-Sha256")
+	if hashedPayload == "" {
+		return AuthV4{}, ErrMissingContentSHA256
+	}
+
+	signedReq := signedRequest(req, auth.SignedHeaders)
+	canonicalRequest := getCanonicalRequest(signedReq, nil, hashedPayload)
+	if !verifier.verify(verifier.stringToSign(canonicalRequest), auth.Signature) {
+		return AuthV4{}, ErrSignatureDoesNotMatch
+	}
+
+	if err = verifyPayload(req, verifier, hashedPayload); err != nil {
+		return AuthV4{}, err
+	}
+	return auth, nil
+}
+
+// VerifyPresignedV4 verifies the signature v4 or v4a query parameters of
+// req, as created by PreSignV4 and PreSignV4A, with the secret key
+// returned by lookup. The credential scope, the expiry and the signed
+// headers are checked, the host header must be signed.
+func VerifyPresignedV4(req *http.Request, lookup CredentialLookup, opts VerifyOptions) (AuthV4, error) {
+	query := req.URL.Query()
+	if !query.Has("X-Amz-Signature") {
+		return AuthV4{}, ErrMissingAuthorization
+	}
+	algorithm := query.Get("X-Amz-Algorithm")
+	if algorithm != signV4Algorithm && algorithm != signV4AAlgorithm {
+		return AuthV4{}, ErrUnsupportedAlgorithm
+	}
+	fields := authV4Fields{
+		algorithm:     algorithm,
+		regionSet:     query.Get("X-Amz-Region-Set"),
+		credential:    query.Get("X-Amz-Credential"),
+		signedHeaders: query.Get("X-Amz-SignedHeaders"),
+		signature:     query.Get("X-Amz-Signature"),
+	}
+
+	t, err := time.Parse(iso8601DateFormat, query.Get("X-Amz-Date"))
+	if err != nil {
+		return AuthV4{}, ErrMalformedDate
+	}
+	expires, err := strconv.ParseInt(query.Get("X-Amz-Expires"), 10, 64)
+	if err != nil || expires <= 0 || time.Duration(expires)*time.Second > maxPresignExpiry {
+		return AuthV4{}, ErrMalformedAuthorization
+	}
+	now := opts.now()
+	if t.Sub(now) > opts.maxClockSkew() {
+		return AuthV4{}, ErrRequestTimeTooSkewed
+	}
+	if now.After(t.Add(time.Duration(expires) * time.Second)) {
+		return AuthV4{}, ErrExpiredRequest
+	}
+
+	auth, verifier, err := parseAuthV4Fields(fields, t, lookup, opts)
+	if err != nil {
+		return AuthV4{}, err
+	}
+	auth.Expires = time.Duration(expires) * time.Second
+
+	signedReq := signedRequest(req, auth.SignedHeaders)
+	query.Del("X-Amz-Signature")
+	signedReq.URL.RawQuery = query.Encode()
+	canonicalRequest := getCanonicalRequest(signedReq, nil, getHashedPayload(signedReq))
+	if !verifier.verify(verifier.stringToSign(canonicalRequest), auth.Signature) {
+		return AuthV4{}, ErrSignatureDoesNotMatch
+	}
+	return auth, nil
+}
+
+// parseRequestTime returns the time of a signed request from the
+// X-Amz-Date or Date header.
+func parseRequestTime(req *http.Request) (time.Time, error) {
+	if v := req.Header.Get("X-Amz-Date"); v != "" {
+		t, err := time.Parse(iso8601DateFormat, v)
+		if err != nil {
+			return time.Time{}, ErrMalformedDate
+		}
+		return t, nil
+	}
+	t, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, ErrMalformedDate
+	}
+	return t.UTC(), nil
+}
+
+// parseAuthV4Fields validates the credential scope and signed headers and
+// looks up the secret key of the access key.
+func parseAuthV4Fields(fields authV4Fields, t time.Time, lookup CredentialLookup, opts VerifyOptions) (AuthV4, signatureVerifier, error) {
+	v4a := fields.algorithm == signV4AAlgorithm
+	validSignature := len(fields.signature) == signatureStrLen
+	// <date>/<region>/<service>/aws4_request, signature v4a scopes
+	// have no region.
+	scopeLen := 4
+	if v4a {
+		validSignature = fields.signature != "" && len(fields.signature) <= v4aSignatureStrLen
+		scopeLen = 3
+	}
+	if fields.credential == "" || fields.signedHeaders == "" || !validSignature {
+		return AuthV4{}, signatureVerifier{}, ErrMalformedAuthorization
+	}
+	// <access-key-id>/<scope>, the access key is split from the right
+	// as it may contain "/".
+	parts := strings.Split(fields.credential, "/")
+	if len(parts) <= scopeLen {
+		return AuthV4{}, signatureVerifier{}, ErrMalformedAuthorization
+	}
+	n := len(parts)
+	auth := AuthV4{
+		AccessKeyID:   strings.Join(parts[:n-scopeLen], "/"),
+		ServiceType:   parts[n-2],
+		Time:          t,
+		SignedHeaders: strings.Split(fields.signedHeaders, ";"),
+		Signature:     fields.signature,
+	}
+	var validRegion bool
+	if v4a {
+		auth.RegionSet = strings.Split(fields.regionSet, ",")
+		validRegion = fields.regionSet != "" && (opts.Region == "" ||
+			slices.Contains(auth.RegionSet, opts.Region) || slices.Contains(auth.RegionSet, "*"))
+	} else {
+		auth.Region = parts[n-3]
+		validRegion = opts.Region == "" || auth.Region == opts.Region
+	}
+	if parts[n-scopeLen] != t.Format(yyyymmdd) || parts[n-1] != "aws4_request" ||
+		auth.ServiceType != opts.serviceType() || !validRegion {
+		return AuthV4{}, signatureVerifier{}, ErrInvalidScope
+	}
+	if !slices.Contains(auth.SignedHeaders, "host") {
+		return AuthV4{}, signatureVerifier{}, ErrUnsignedHeader
+	}
+
+	secretAccessKey, err := lookup(auth.AccessKeyID)
+	if err != nil {
+		return AuthV4{}, signatureVerifier{}, err
+	}
+	verifier := signatureVerifier{auth: auth}
+	if v4a {
+		key, err := DeriveV4AKey(auth.AccessKeyID, secretAccessKey)
+		if err != nil {
+			return AuthV4{}, signatureVerifier{}, err
+		}
+		verifier.publicKey = &key.PublicKey
+	} else {
+		verifier.signingKey = getSigningKey(secretAccessKey, auth.Region, t, auth.ServiceType)
+	}
+	return auth, verifier, nil
+}
+
+// signatureVerifier verifies the signatures of a request with the key of
+// its access key.
+type signatureVerifier struct {
+	auth       AuthV4
+	signingKey []byte           // signature v4
+	publicKey  *ecdsa.PublicKey // signature v4a
+}
+
+func (v signatureVerifier) stringToSign(canonicalRequest string) string {
+	if v.publicKey != nil {
+		return getStringToSignV4A(v.auth.Time, canonicalRequest, v.auth.ServiceType)
+	}
+	return getStringToSignV4(v.auth.Time, v.auth.Region, canonicalRequest, v.auth.ServiceType)
+}
+
+func (v signatureVerifier) chunkStringToSign(previousSignature, chunkChecksum string) string {
+	if v.publicKey != nil {
+		return buildChunkStringToSignV4A(v.auth.Time, v.auth.ServiceType, previousSignature, chunkChecksum)
+	}
+	return buildChunkStringToSignWithService(v.auth.Time, v.auth.Region, previousSignature, chunkChecksum, v.auth.ServiceType)
+}
+
+func (v signatureVerifier) trailerStringToSign(previousSignature, chunkChecksum string) string {
+	if v.publicKey != nil {
+		return buildTrailerChunkStringToSignV4A(v.auth.Time, v.auth.ServiceType, previousSignature, chunkChecksum)
+	}
+	return buildTrailerChunkStringToSignWithService(v.auth.Time, v.auth.Region, previousSignature, chunkChecksum, v.auth.ServiceType)
+}
+
+// verify returns true if signature is a valid signature of stringToSign.
+func (v signatureVerifier) verify(stringToSign, signature string) bool {
+	if v.publicKey != nil {
+		sig, err := hex.DecodeString(signature)
+		return err == nil && ecdsa.VerifyASN1(v.publicKey, sum256([]byte(stringToSign)), sig)
+	}
+	return hmac.Equal([]byte(getSignature(v.signingKey, stringToSign)), []byte(signature))
+}
+
+// signedRequest returns a copy of req with only the signed headers, for
+// computing the canonical request.
+func signedRequest(req *http.Request, signedHeaders []string) http.Request {
+	signedReq := *req
+	u := *req.URL
+	signedReq.URL = &u
+	signedReq.Header = make(http.Header, len(signedHeaders))
+	for _, k := range signedHeaders {
+		if k == "host" {
+			continue
+		}
+		ck := http.CanonicalHeaderKey(k)
+		if vv, ok := req.Header[ck]; ok {
+			signedReq.Header[ck] = vv
+			continue
+		}
+		// Go servers remove these from the request headers.
+		switch ck {
+		case "Content-Length":
+			signedReq.Header.Set(ck, strconv.FormatInt(req.ContentLength, 10))
+		case "Transfer-Encoding":
+			signedReq.Header.Set(ck, strings.Join(req.TransferEncoding, ","))
+		default:
+			signedReq.Header[ck] = []string{""}
+		}
+	}
+	return signedReq
+}
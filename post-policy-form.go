@@ -0,0 +1,188 @@
+/*
+ * MinIO Go Library for Amazon S3 Compatible Cloud Storage
+ * Copyright 2026 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package minio
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// errPostPolicy - Form fields of a POST upload violate its policy.
+func errPostPolicy(message string) error {
+	return ErrorResponse{
+		StatusCode: http.StatusForbidden,
+		Code:       AccessDenied,
+		Message:    "Invalid according to Policy: " + message,
+		RequestID:  "minio",
+	}
+}
+
+// isPostPolicyIgnoredField reports whether a form field is exempt from
+// the policy conditions.
+func isPostPolicyIgnoredField(name string) bool {
+	switch name {
+	case "policy", "x-amz-signature", "file", "signature", "awsaccesskeyid":
+		return true
+	}
+	return strings.HasPrefix(name, "x-ignore-")
+}
+
+// Validate evaluates the policy against the form fields and the file size
+// of a POST upload the way the server does, such that uploads it would
+// reject can be rejected before they are sent.
+//
+// Form field names are matched case-insensitively and must include the
+// fields of the presigned form data, including "bucket". As on the server
+// every form field, except policy, x-amz-signature, file and fields
+// prefixed with x-ignore-, must be covered by a condition of the policy.
+// The encryption fields added by SetEncryption are exempt, they are not
+// conditions of the policy.
+//
+// fileName is the name of the uploaded file, which replaces ${filename}
+// in the key. If it is empty only the part of the key before ${filename}
+// is checked.
+func (p *PostPolicy) Validate(formFields map[string]string, fileName string, fileSize int64) error {
+	if time.Now().After(p.expiration) {
+		return errPostPolicy("Policy expired.")
+	}
+
+	fields := make(map[string]string, len(formFields))
+	for name, value := range formFields {
+		fields[strings.ToLower(name)] = value
+	}
+	encryption := make(map[string]bool)
+	for name := range p.formData {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-amz-server-side-encryption") {
+			encryption[name] = true
+		}
+	}
+
+	// The server replaces ${filename} in the key with the name of the
+	// uploaded file before the conditions are evaluated.
+	key, keyPrefixOnly := fields["key"], false
+	if before, after, ok := strings.Cut(key, "${filename}"); ok {
+		if fileName != "" {
+			key = before + fileName + after
+		} else {
+			key, keyPrefixOnly = before, true
+		}
+	}
+
+	conditioned := make(map[string]bool, len(p.conditions))
+	for _, cond := range p.conditions {
+		name := strings.ToLower(strings.TrimPrefix(cond.condition, "$"))
+		conditioned[name] = true
+
+		value := fields[name]
+		if name == "key" {
+			value = key
+		}
+		var ok bool
+		switch {
+		case name == "key" && keyPrefixOnly:
+			// The file name could complete the key to match.
+			ok = strings.HasPrefix(cond.value, key) || cond.matchType == "starts-with" && strings.HasPrefix(key, cond.value)
+		case cond.matchType == "eq":
+			ok = value == cond.value
+		case cond.matchType == "starts-with":
+			if name == "content-type" {
+				// Every content type of a comma-separated list must match.
+				ok = true
+				for _, v := range strings.Split(value, ",") {
+					ok = ok && strings.HasPrefix(strings.TrimSpace(v), cond.value)
+				}
+			} else {
+				ok = strings.HasPrefix(value, cond.value)
+			}
+		}
+		if !ok {
+			return errPostPolicy(fmt.Sprintf("Policy Condition failed: [\"%s\", \"%s\", \"%s\"]", cond.matchType, cond.condition, cond.value))
+		}
+	}
+
+	if p.contentLengthRange.min != 0 || p.contentLengthRange.max != 0 {
+		if fileSize < p.contentLengthRange.min {
+			return ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Code:       EntityTooSmall,
+				Message:    fmt.Sprintf("Your proposed upload size ‘%d’ is below the minimum allowed size ‘%d’.", fileSize, p.contentLengthRange.min),
+				RequestID:  "minio",
+			}
+		}
+		if fileSize > p.contentLengthRange.max {
+			return ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Code:       EntityTooLarge,
+				Message:    fmt.Sprintf("Your proposed upload size ‘%d’ exceeds the maximum allowed size ‘%d’.", fileSize, p.contentLengthRange.max),
+				RequestID:  "minio",
+			}
+		}
+	}
+
+	for name := range fields {
+		if !conditioned[name] && !encryption[name] && !isPostPolicyIgnoredField(name) {
+			return errPostPolicy(fmt.Sprintf("Extra input fields: %s", name))
+		}
+	}
+	return nil
+}
+
+// PostForm is a ready-to-use POST upload, as returned by
+// PresignedPostPolicy. It encodes to JSON as {"url": ..., "fields": ...}
+// for clients that build the multipart/form-data request themselves.
+type PostForm struct {
+	URL    string            `json:"url"`
+	Fields map[string]string `json:"fields"`
+}
+
+// NewPostForm returns the POST upload of the URL and form data returned
+// by PresignedPostPolicy.
+func NewPostForm(u *url.URL, formData map[string]string) PostForm {
+	return PostForm{URL: u.String(), Fields: formData}
+}
+
+// postFormTemplate renders a PostForm, the file input comes last as the
+// server ignores all fields after the file.
+var postFormTemplate = template.Must(template.New("form").Parse(`<form action="{{.URL}}" method="post" enctype="multipart/form-data">
+{{- range $name, $value := .Fields}}
+  <input type="hidden" name="{{$name}}" value="{{$value}}">
+{{- end}}
+  <input type="file" name="file">
+  <input type="submit" value="Upload">
+</form>
+`))
+
+// HTML renders the upload as an HTML form with its fields as hidden inputs
+// followed by the file input.
+func (f PostForm) HTML() (string, error) {
+	var sb strings.Builder
+	if err := postFormTemplate.Execute(&sb, f); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// JSON returns the upload encoded as JSON.
+func (f PostForm) JSON() ([]byte, error) {
+	return json.Marshal(f)
+}
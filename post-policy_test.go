@@ -18,6 +18,7 @@
 package minio
 
 import (
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -458,3 +459,138 @@ func TestPostPolicySetEncryption(t *testing.T) {
 		})
 	}
 }
+
+func TestPostPolicyValidate(t *testing.T) {
+	pp := NewPostPolicy()
+	if err := pp.SetExpires(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.SetBucket("bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.SetKeyStartsWith("uploads/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.SetContentTypeStartsWith("image/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.SetContentLengthRange(1, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.SetChecksum(ChecksumCRC32.ChecksumBytes([]byte("somerandomdata"))); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := func() map[string]string {
+		return map[string]string{
+			"bucket":                   "bucket",
+			"Key":                      "uploads/photo.png",
+			"Content-Type":             "image/png",
+			"x-amz-checksum-algorithm": "CRC32",
+			"x-amz-checksum-crc32":     "7sOPnw==",
+			"policy":                   "...",
+			"x-amz-signature":          "...",
+			"x-ignore-origin":          "form",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(map[string]string)
+		fileName string
+		size     int64
+		wantCode string
+	}{
+		{name: "valid", size: 512},
+		{name: "key prefix", modify: func(f map[string]string) { f["Key"] = "other/photo.png" }, size: 512, wantCode: AccessDenied},
+		{name: "missing bucket", modify: func(f map[string]string) { delete(f, "bucket") }, size: 512, wantCode: AccessDenied},
+		{name: "content type list", modify: func(f map[string]string) { f["Content-Type"] = "image/png, image/jpeg" }, size: 512},
+		{name: "content type list mismatch", modify: func(f map[string]string) { f["Content-Type"] = "image/png,text/plain" }, size: 512, wantCode: AccessDenied},
+		{name: "checksum mismatch", modify: func(f map[string]string) { f["x-amz-checksum-crc32"] = "AAAAAA==" }, size: 512, wantCode: AccessDenied},
+		{name: "extra field", modify: func(f map[string]string) { f["x-amz-meta-owner"] = "me" }, size: 512, wantCode: AccessDenied},
+		{name: "filename", modify: func(f map[string]string) { f["Key"] = "uploads/${filename}" }, fileName: "photo.png", size: 512},
+		{name: "filename mismatch", modify: func(f map[string]string) { f["Key"] = "${filename}" }, fileName: "photo.png", size: 512, wantCode: AccessDenied},
+		{name: "filename unknown", modify: func(f map[string]string) { f["Key"] = "uploads/${filename}" }, size: 512},
+		{name: "filename key prefix", modify: func(f map[string]string) { f["Key"] = "other/${filename}" }, size: 512, wantCode: AccessDenied},
+		{name: "too small", size: 0, wantCode: EntityTooSmall},
+		{name: "too large", size: 1025, wantCode: EntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := valid()
+			if tt.modify != nil {
+				tt.modify(fields)
+			}
+			err := pp.Validate(fields, tt.fileName, tt.size)
+			if code := ToErrorResponse(err).Code; code != tt.wantCode {
+				t.Fatalf("want code %q, got %v", tt.wantCode, err)
+			}
+		})
+	}
+
+	expired := NewPostPolicy()
+	expired.expiration = time.Now().Add(-time.Minute)
+	if err := expired.Validate(nil, "", 0); ToErrorResponse(err).Code != AccessDenied {
+		t.Fatalf("expected expired policy to be rejected, got %v", err)
+	}
+}
+
+func TestPostPolicyValidateEncryption(t *testing.T) {
+	sse, err := encrypt.NewSSEC([]byte("my-secret-key1234567890abcdefghi"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp := NewPostPolicy()
+	if err = pp.SetExpires(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err = pp.SetBucket("bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if err = pp.SetKey("photo.png"); err != nil {
+		t.Fatal(err)
+	}
+	pp.SetEncryption(sse)
+
+	fields := map[string]string{"policy": "...", "x-amz-signature": "..."}
+	for k, v := range pp.formData {
+		fields[k] = v
+	}
+	if err = pp.Validate(fields, "", 512); err != nil {
+		t.Fatalf("expected SSE-C form to be valid, got %v", err)
+	}
+}
+
+func TestPostForm(t *testing.T) {
+	u, err := url.Parse("https://play.min.io/bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := NewPostForm(u, map[string]string{
+		"key":    "uploads/<photo>.png",
+		"policy": "eyJ9",
+	})
+
+	html, err := form.HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<form action="https://play.min.io/bucket" method="post" enctype="multipart/form-data">
+  <input type="hidden" name="key" value="uploads/&lt;photo&gt;.png">
+  <input type="hidden" name="policy" value="eyJ9">
+  <input type="file" name="file">
+  <input type="submit" value="Upload">
+</form>
+`
+	if html != want {
+		t.Fatalf("unexpected form:\n%s", html)
+	}
+
+	data, err := form.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"url":"https://play.min.io/bucket","fields":{"key":"uploads/\u003cphoto\u003e.png","policy":"eyJ9"}}` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+}